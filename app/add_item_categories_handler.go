@@ -0,0 +1,66 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type AddItemCategoriesHandler struct {
+	editor itemCategoriesEditor
+}
+
+func NewAddItemCategoriesHandler(repository Repository, eventPublisher events.Publisher) *AddItemCategoriesHandler {
+	return &AddItemCategoriesHandler{
+		editor: itemCategoriesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type AddItemCategoriesRequest struct {
+	ItemID      string   `params:"id" validate:"required,uuid"`
+	CategoryIDs []string `json:"categoryIds" validate:"required,min=1,unique,dive,uuid"`
+}
+
+type AddItemCategoriesResponse struct {
+	Categories []domain.Category `json:"categories"`
+}
+
+// Handle assigns categories to one of the user's items, keeping the ones it has
+func (h AddItemCategoriesHandler) Handle(ctx context.Context, req *AddItemCategoriesRequest) (*AddItemCategoriesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.categories.add.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.categories.add.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	categories, err := h.editor.edit(ctx, "item.categories.add", req.ItemID, req.CategoryIDs, func(ctx context.Context, itemID string) ([]string, []string, error) {
+		added, err := h.editor.repository.AddItemCategories(ctx, itemID, req.CategoryIDs)
+
+		return added, nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddItemCategoriesResponse{
+		Categories: categories,
+	}, nil
+}
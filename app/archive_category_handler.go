@@ -0,0 +1,70 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type ArchiveCategoryHandler struct {
+	repository Repository
+}
+
+func NewArchiveCategoryHandler(repository Repository) *ArchiveCategoryHandler {
+	return &ArchiveCategoryHandler{
+		repository: repository,
+	}
+}
+
+type ArchiveCategoryRequest struct {
+	ID string `params:"id" validate:"required,uuid"`
+}
+
+type ArchiveCategoryResponse struct {
+	Category domain.Category `json:"category"`
+}
+
+// Handle archives a category. Its items keep the category, but it and its
+// subcategories drop out of the category tree. Setting the status back to
+// active with UpdateCategoryRequest restores them.
+func (h ArchiveCategoryHandler) Handle(ctx context.Context, req *ArchiveCategoryRequest) (*ArchiveCategoryResponse, error) {
+	category, err := h.repository.GetCategoryByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.archive.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.archive.failed",
+			"Failed to get category",
+			nil,
+		)
+	}
+
+	if category.IsArchived() {
+		return &ArchiveCategoryResponse{
+			Category: category,
+		}, nil
+	}
+
+	category.Status = domain.CategoryStatusArchived
+
+	archived, err := h.repository.UpdateCategory(ctx, category)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.archive.failed",
+			"Failed to archive category",
+			nil,
+		)
+	}
+
+	return &ArchiveCategoryResponse{
+		Category: archived,
+	}, nil
+}
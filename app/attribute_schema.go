@@ -0,0 +1,30 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+)
+
+// loadAttributeSchema returns the union of the attribute schemas of the categories
+func loadAttributeSchema(ctx context.Context, repository Repository, categoryIDs []string) (domain.AttributeSchema, error) {
+	if len(categoryIDs) == 0 {
+		return domain.AttributeSchema{}, nil
+	}
+
+	definitions, err := repository.GetCategoryAttributes(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewAttributeSchema(definitions), nil
+}
+
+// invalidAttributes reports attributes that do not match the schema, one violation per field
+func invalidAttributes(code string, violations []domain.AttributeViolation) error {
+	return httperror.UnprocessableEntity(
+		code,
+		"Attributes do not match the category attribute schema",
+		map[string]any{"violations": violations},
+	)
+}
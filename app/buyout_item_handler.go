@@ -0,0 +1,167 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type BuyoutItemHandler struct {
+	repository     Repository
+	eventPublisher events.Publisher
+}
+
+type BuyoutItemRequest struct {
+	ItemID string `params:"id" validate:"required,uuid"`
+}
+
+type BuyoutItemResponse struct {
+	Item PublicItem `json:"item"`
+}
+
+func NewBuyoutItemHandler(repository Repository, eventPublisher events.Publisher) *BuyoutItemHandler {
+	return &BuyoutItemHandler{
+		repository:     repository,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (h BuyoutItemHandler) Handle(ctx context.Context, req *BuyoutItemRequest) (*BuyoutItemResponse, error) {
+	buyerID := ctx.Value("UserID").(string)
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.buyout.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.buyout.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	item, err := h.repository.GetItem(ctx, req.ItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"item.buyout.not_found",
+				"Item not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.buyout.failed",
+			"Failed to get item",
+			nil,
+		)
+	}
+
+	if err := item.Buyout(buyerID, time.Now().UTC()); err != nil {
+		var buyoutErr *domain.BuyoutError
+		if errors.As(err, &buyoutErr) {
+			return nil, httperror.Conflict(
+				"item.buyout.unavailable",
+				"Buyout is not available for this item",
+				map[string]any{"reason": buyoutErr.Reason},
+			).WithCause(buyoutErr)
+		}
+
+		return nil, statusConflict("item.buyout.illegal_transition", err)
+	}
+
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.Update(ctx, item); err != nil {
+			return err
+		}
+
+		item.Version++
+
+		return h.publishEvents(ctx, item)
+	})
+	if err != nil {
+		if errors.Is(err, ErrOptimisticLock) {
+			return nil, httperror.Conflict(
+				"item.buyout.conflict",
+				"The item was modified by another request, please retry",
+				nil,
+			).WithCause(ErrOptimisticLock)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.buyout.failed",
+			"Failed to buy out item",
+			nil,
+		)
+	}
+
+	return &BuyoutItemResponse{
+		Item: NewPublicItem(item),
+	}, nil
+}
+
+func (h BuyoutItemHandler) publishEvents(ctx context.Context, item domain.Item) error {
+	if h.eventPublisher == nil {
+		return nil
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	endedEvent := events.NewEvent(
+		events.ItemEndedEvent,
+		events.EventVersionV1,
+		events.ItemEndedPayload{
+			ID:           item.ID,
+			SellerID:     item.SellerID,
+			Status:       string(item.Status),
+			CurrencyCode: item.CurrencyCode,
+			FinalPrice:   item.EndPrice,
+			BidCount:     item.BidCount,
+			EndedAt:      item.UpdatedAt,
+		},
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, endedEvent, headers); err != nil {
+		return fmt.Errorf("failed to publish item.ended event: %w", err)
+	}
+
+	soldEvent := events.NewEvent(
+		events.ItemSoldEvent,
+		events.EventVersionV1,
+		events.ItemSoldPayload{
+			ID:           item.ID,
+			SellerID:     item.SellerID,
+			BuyerID:      item.BuyerID,
+			CurrencyCode: item.CurrencyCode,
+			FinalPrice:   item.CurrentPrice,
+			Reason:       events.ItemSoldReasonBuyout,
+			SoldAt:       item.UpdatedAt,
+		},
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, soldEvent, headers); err != nil {
+		return fmt.Errorf("failed to publish item.sold event: %w", err)
+	}
+
+	return nil
+}
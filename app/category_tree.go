@@ -0,0 +1,32 @@
+package app
+
+import "auction/domain"
+
+// CategoryNode is a category with its subcategories
+type CategoryNode struct {
+	domain.Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// NewCategoryTree nests categories under their parents. Parents must come
+// before their children; categories whose parent is missing become roots.
+func NewCategoryTree(categories []domain.Category) []*CategoryNode {
+	roots := make([]*CategoryNode, 0)
+	nodes := make(map[string]*CategoryNode, len(categories))
+
+	for _, category := range categories {
+		node := &CategoryNode{Category: category, Children: []*CategoryNode{}}
+		nodes[category.ID] = node
+
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
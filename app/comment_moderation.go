@@ -0,0 +1,227 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ModerationVerdict string
+
+// Verdicts of a CommentModerator
+const (
+	ModerationApprove ModerationVerdict = "approve" // Publish the comment
+	ModerationHold    ModerationVerdict = "hold"    // Keep it pending until a moderator releases it
+	ModerationReject  ModerationVerdict = "reject"  // Refuse to store it
+)
+
+type ModerationDecision struct {
+	Verdict ModerationVerdict
+	Reason  string
+}
+
+// CommentModerator reviews comments before they are stored. comment has the
+// item, author, parent and content set.
+type CommentModerator interface {
+	ReviewComment(ctx context.Context, comment domain.ItemComment) (ModerationDecision, error)
+}
+
+// reviewComment runs moderator on a new or edited comment and returns the
+// status the comment is stored with. A nil moderator publishes every comment.
+// Codes of errors are prefixed with action, e.g. "comment.update".
+func reviewComment(ctx context.Context, moderator CommentModerator, action string, comment domain.ItemComment) (domain.CommentStatus, error) {
+	if moderator == nil {
+		return domain.CommentStatusVisible, nil
+	}
+
+	decision, err := moderator.ReviewComment(ctx, comment)
+	if err != nil {
+		// Without a verdict the comment waits for a moderator
+		return domain.CommentStatusPending, nil
+	}
+
+	switch decision.Verdict {
+	case ModerationHold:
+		return domain.CommentStatusPending, nil
+	case ModerationReject:
+		return "", httperror.UnprocessableEntity(
+			action+".rejected",
+			"The comment was rejected by moderation",
+			map[string]any{"reason": decision.Reason},
+		)
+	default:
+		return domain.CommentStatusVisible, nil
+	}
+}
+
+// ReviewedComment is a comment in the moderation queue
+type ReviewedComment struct {
+	domain.ItemComment
+	ReportCount int `json:"report_count" db:"report_count"`
+}
+
+var errCommentModeratedConcurrently = errors.New("comment was moderated concurrently")
+
+// commentModeration changes the status of comments on behalf of moderators
+// and sellers. The hide and unhide handlers only differ in the status.
+type commentModeration struct {
+	repository     Repository
+	eventPublisher events.Publisher
+}
+
+// moderate moves a comment of an item to status. Moderators may moderate any
+// comment. The seller of the item may hide its visible comments and unhide the
+// ones they hid themselves. Codes of errors are prefixed with action, e.g.
+// "comment.hide".
+func (m commentModeration) moderate(ctx context.Context, action string, itemID string, commentID string, status domain.CommentStatus, reason *string) (domain.ItemComment, error) {
+	userID := ctx.Value("UserID").(string)
+
+	comment, err := m.repository.GetCommentByID(ctx, commentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.ItemComment{}, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get comment",
+			nil,
+		)
+	}
+	if err != nil || comment.ItemID != itemID {
+		return domain.ItemComment{}, httperror.NotFound(
+			action+".not_found",
+			"Comment not found",
+			nil,
+		)
+	}
+
+	if !hasRole(ctx, RoleModerator) {
+		item, err := m.repository.GetItem(ctx, itemID)
+		if err != nil {
+			return domain.ItemComment{}, httperror.InternalServerError(
+				action+".failed",
+				"Failed to get item",
+				nil,
+			)
+		}
+
+		if !sellerMayModerate(item, comment, status, userID) {
+			return domain.ItemComment{}, httperror.Forbidden(
+				action+".forbidden",
+				"Only moderators and the seller can moderate this comment",
+				nil,
+			)
+		}
+	}
+
+	previous := comment.Status
+	// Comments held by the moderator at creation were never published. Held
+	// edits were, since only visible comments can be edited.
+	heldAtCreation := previous == domain.CommentStatusPending && comment.ModeratedAt == nil && comment.EditedAt == nil
+
+	changed, err := comment.Moderate(status, &userID, reason, time.Now().UTC())
+	if err != nil {
+		return domain.ItemComment{}, httperror.Conflict(
+			action+".deleted",
+			"Deleted comments cannot be moderated",
+			nil,
+		)
+	}
+	if !changed {
+		return comment, nil
+	}
+
+	var moderated domain.ItemComment
+	err = m.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		moderated, err = m.repository.UpdateCommentStatus(ctx, comment, previous)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errCommentModeratedConcurrently
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := publishCommentModerated(ctx, m.eventPublisher, previous, moderated); err != nil {
+			return err
+		}
+
+		if !heldAtCreation || !moderated.IsAnswer() || !moderated.IsVisible() {
+			return nil
+		}
+
+		question, err := m.repository.GetCommentByID(ctx, *moderated.ParentID)
+		if err != nil {
+			return err
+		}
+
+		return publishQuestionAnswered(ctx, m.eventPublisher, question, moderated)
+	})
+	if err != nil {
+		if errors.Is(err, errCommentModeratedConcurrently) {
+			return domain.ItemComment{}, httperror.Conflict(
+				action+".conflict",
+				"The comment was moderated by someone else, retry",
+				nil,
+			)
+		}
+
+		return domain.ItemComment{}, httperror.InternalServerError(
+			action+".failed",
+			"Failed to moderate comment",
+			nil,
+		)
+	}
+
+	return moderated, nil
+}
+
+// sellerMayModerate reports whether the seller of item may move comment to status
+func sellerMayModerate(item domain.Item, comment domain.ItemComment, status domain.CommentStatus, userID string) bool {
+	if item.SellerID != userID {
+		return false
+	}
+
+	switch status {
+	case domain.CommentStatusHidden:
+		return comment.Status == domain.CommentStatusVisible
+	case domain.CommentStatusVisible:
+		return comment.Status == domain.CommentStatusHidden && comment.ModeratedBy != nil && *comment.ModeratedBy == userID
+	default:
+		return false
+	}
+}
+
+func publishCommentModerated(ctx context.Context, eventPublisher events.Publisher, previous domain.CommentStatus, comment domain.ItemComment) error {
+	eventPayload := events.ItemCommentModeratedPayload{
+		ID:             comment.ID,
+		ItemID:         comment.ItemID,
+		AuthorID:       comment.UserID,
+		Status:         string(comment.Status),
+		PreviousStatus: string(previous),
+		ModeratorID:    comment.ModeratedBy,
+		Reason:         comment.ModerationReason,
+		ModeratedAt:    *comment.ModeratedAt,
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemCommentModeratedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.comment.moderated event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package app
+
+import "auction/domain"
+
+// CommentReply is a reply with the number of its direct replies
+type CommentReply struct {
+	domain.ItemComment
+	ReplyCount int `json:"reply_count" db:"reply_count"`
+}
+
+// CommentReplies are the first replies of a root comment, in the order they
+// were written, from anywhere in its reply tree
+type CommentReplies struct {
+	Replies      []CommentReply
+	ReplyCount   int // Direct replies of the root
+	TotalReplies int // Replies in the whole tree
+}
+
+// CommentThread is a comment with the replies to it that were loaded. Counts
+// tell clients whether more replies can be fetched.
+type CommentThread struct {
+	domain.ItemComment
+	ReplyCount   int              `json:"reply_count"`
+	TotalReplies *int             `json:"total_replies,omitempty"` // Only set on root comments
+	Replies      []*CommentThread `json:"replies"`
+}
+
+// newCommentThreads nests the loaded replies of each root under their parents
+func newCommentThreads(roots []domain.ItemComment, replies map[string]CommentReplies) []*CommentThread {
+	threads := make([]*CommentThread, len(roots))
+
+	for i, root := range roots {
+		loaded := replies[root.ID]
+		thread := &CommentThread{
+			ItemComment:  root.Redacted(),
+			ReplyCount:   loaded.ReplyCount,
+			TotalReplies: &loaded.TotalReplies,
+			Replies:      []*CommentThread{},
+		}
+
+		nodes := map[string]*CommentThread{root.ID: thread}
+		for _, reply := range loaded.Replies {
+			node := &CommentThread{
+				ItemComment: reply.ItemComment.Redacted(),
+				ReplyCount:  reply.ReplyCount,
+				Replies:     []*CommentThread{},
+			}
+			nodes[reply.ID] = node
+
+			// Replies come after their parent, which is only missing when it
+			// was written in the same instant; keep those under the root
+			parent, ok := nodes[*reply.ParentID]
+			if !ok {
+				parent = thread
+			}
+			parent.Replies = append(parent.Replies, node)
+		}
+
+		threads[i] = thread
+	}
+
+	return threads
+}
+
+func replyCursorKey(reply CommentReply) (string, string) {
+	return formatCursorTime(reply.CreatedAt), reply.ID
+}
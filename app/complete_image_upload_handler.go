@@ -0,0 +1,263 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/aws"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// errImageUploadCompleted is returned when an upload was completed by a
+// concurrent request
+var errImageUploadCompleted = errors.New("image upload already completed")
+
+// Image types accepted from presigned uploads, detected from the content
+var uploadedImageTypes = []string{"image/png", "image/jpeg"}
+
+type CompleteImageUploadHandler struct {
+	repository     Repository
+	eventPublisher events.Publisher
+	maxImages      int
+	maxBytes       int64
+}
+
+// NewCompleteImageUploadHandler creates the handler. Uploads larger than
+// maxBytes are refused; items hold at most maxImages images, 0 means no limit.
+func NewCompleteImageUploadHandler(repository Repository, eventPublisher events.Publisher, maxImages int, maxBytes int64) *CompleteImageUploadHandler {
+	return &CompleteImageUploadHandler{
+		repository:     repository,
+		eventPublisher: eventPublisher,
+		maxImages:      maxImages,
+		maxBytes:       maxBytes,
+	}
+}
+
+type CompleteImageUploadRequest struct {
+	ItemID  string `params:"itemId" validate:"required,uuid"`
+	ImageID string `params:"imageId" validate:"required,uuid"` // The id of the upload
+}
+
+type CompleteImageUploadResponse struct {
+	Image domain.ItemImage `json:"image"`
+}
+
+// Handle checks the object uploaded with a presigned URL and adds it to the
+// images of the item. Refused objects are deleted along with the upload.
+// Completing an upload twice returns the image.
+func (h *CompleteImageUploadHandler) Handle(ctx context.Context, req *CompleteImageUploadRequest) (*CompleteImageUploadResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item_images.complete.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item_images.complete.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	userID := ctx.Value("UserID").(string)
+
+	item, err := h.repository.GetItem(ctx, req.ItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound("item_images.complete.not_found", "Item not found", nil)
+		}
+
+		return nil, httperror.InternalServerError("item_images.complete.failed", "Failed to get item", nil)
+	}
+	if item.SellerID != userID {
+		return nil, httperror.Forbidden("item_images.complete.forbidden", "You are not authorized to upload images for this item", nil)
+	}
+
+	upload, err := h.repository.GetImageUpload(ctx, item.ID, req.ImageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return h.completed(ctx, item.ID, req.ImageID)
+	}
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.complete.failed", "Failed to get upload", nil)
+	}
+
+	if upload.IsExpired(time.Now().UTC()) {
+		return nil, httperror.Gone("item_images.complete.expired", "The upload URL has expired, request a new one", nil)
+	}
+
+	bucket := aws.NewS3Bucket()
+
+	// The presigned URL can still be used after the upload is completed, so the
+	// object is copied to a key clients never get a URL for and checked there
+	key, err := h.copyObject(ctx, bucket, upload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.checkObject(ctx, bucket, upload, key); err != nil {
+		return nil, err
+	}
+
+	var image domain.ItemImage
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockItemImages(ctx, item.ID); err != nil {
+			return err
+		}
+
+		// The upload reserved its slot, so only completed images count here
+		count, err := h.repository.CountItemImages(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkImageLimit("item_images.complete.too_many_images", h.maxImages, count, 1); err != nil {
+			return err
+		}
+
+		image, err = h.repository.CompleteImageUpload(ctx, upload, aws.ObjectURL(key))
+		if errors.Is(err, sql.ErrNoRows) {
+			return errImageUploadCompleted
+		}
+		if err != nil {
+			return err
+		}
+
+		return publishImageUploaded(ctx, h.eventPublisher, image)
+	})
+	if err != nil {
+		// The copy of a concurrent request may have been completed instead
+		_ = bucket.Delete(key)
+
+		var httpErr *httperror.Error
+		switch {
+		case errors.Is(err, errImageUploadCompleted):
+			return h.completed(ctx, item.ID, req.ImageID)
+		case errors.As(err, &httpErr):
+			return nil, httpErr
+		}
+
+		return nil, httperror.InternalServerError("item_images.complete.failed", "Failed to save image metadata", nil)
+	}
+
+	// Without its upload the object is no longer swept, whatever is uploaded
+	// to it later is left to the expiry rule of the uploads/ prefix
+	_ = bucket.Delete(upload.ObjectKey)
+
+	return &CompleteImageUploadResponse{
+		Image: image,
+	}, nil
+}
+
+// copyObject copies the uploaded object to a new key of the item's images
+// and returns the key. Every request copies to its own key, so what it checks
+// cannot be overwritten by a concurrent request. Objects that are too large
+// are rejected before they are copied.
+func (h *CompleteImageUploadHandler) copyObject(ctx context.Context, bucket *aws.S3, upload domain.ItemImageUpload) (string, error) {
+	info, err := bucket.Stat(ctx, upload.ObjectKey)
+	if errors.Is(err, aws.ErrObjectNotFound) {
+		return "", httperror.Conflict("item_images.complete.not_uploaded", "The image has not been uploaded yet", nil)
+	}
+	if err != nil {
+		return "", httperror.InternalServerError("item_images.complete.failed", "Failed to check the uploaded image", nil)
+	}
+
+	if err := h.checkSize(ctx, bucket, upload, "", info.Size); err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("items/%s/%s%s", upload.ItemID, uuid.New().String(), path.Ext(upload.ObjectKey))
+	if err := bucket.Copy(ctx, upload.ObjectKey, key, upload.ContentType); err != nil {
+		return "", httperror.InternalServerError("item_images.complete.failed", "Failed to store the uploaded image", nil)
+	}
+
+	return key, nil
+}
+
+// checkObject verifies the copy of the uploaded object under key is small
+// enough and is a PNG or JPEG image. Objects failing the checks are rejected.
+func (h *CompleteImageUploadHandler) checkObject(ctx context.Context, bucket *aws.S3, upload domain.ItemImageUpload, key string) error {
+	info, err := bucket.Stat(ctx, key)
+	if err != nil {
+		return httperror.InternalServerError("item_images.complete.failed", "Failed to check the uploaded image", nil)
+	}
+
+	// The upload may have been replaced between the Stat and the copy
+	if err := h.checkSize(ctx, bucket, upload, key, info.Size); err != nil {
+		return err
+	}
+
+	// The Content-Type of the object is whatever the client sent
+	head, err := bucket.DownloadHead(ctx, key, 512)
+	if err != nil {
+		return httperror.InternalServerError("item_images.complete.failed", "Failed to check the uploaded image", nil)
+	}
+
+	detected := http.DetectContentType(head)
+	if !slices.Contains(uploadedImageTypes, detected) {
+		h.reject(ctx, bucket, upload, key)
+
+		return httperror.UnprocessableEntity("item_images.complete.invalid_content_type", "Only PNG, JPEG/JPG images are allowed",
+			map[string]any{
+				"received": detected,
+				"allowed":  uploadedImageTypes,
+			})
+	}
+
+	return nil
+}
+
+// checkSize rejects the upload when size exceeds the limit. key is the copy
+// of the upload, empty before it is made.
+func (h *CompleteImageUploadHandler) checkSize(ctx context.Context, bucket *aws.S3, upload domain.ItemImageUpload, key string, size int64) error {
+	if h.maxBytes <= 0 || size <= h.maxBytes {
+		return nil
+	}
+
+	h.reject(ctx, bucket, upload, key)
+
+	return httperror.UnprocessableEntity("item_images.complete.file_too_large", "The uploaded image is too large",
+		map[string]int64{
+			"size":      size,
+			"max_bytes": h.maxBytes,
+		})
+}
+
+// reject deletes a refused object, its copy under key if one was made and its upload,
+// freeing the image slot. Leftovers are removed by the upload sweeper once
+// the upload expires.
+func (h *CompleteImageUploadHandler) reject(ctx context.Context, bucket *aws.S3, upload domain.ItemImageUpload, key string) {
+	if key != "" {
+		_ = bucket.Delete(key)
+	}
+	_ = bucket.Delete(upload.ObjectKey)
+	_ = h.repository.DeleteImageUpload(ctx, upload.ID)
+}
+
+// completed returns the image an upload became, for uploads completed before
+func (h *CompleteImageUploadHandler) completed(ctx context.Context, itemID string, imageID string) (*CompleteImageUploadResponse, error) {
+	image, err := h.repository.GetItemImage(ctx, itemID, imageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.NotFound("item_images.complete.upload_not_found", "Upload not found", nil)
+	}
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.complete.failed", "Failed to get image", nil)
+	}
+
+	return &CompleteImageUploadResponse{
+		Image: image,
+	}, nil
+}
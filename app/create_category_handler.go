@@ -0,0 +1,102 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateCategoryHandler struct {
+	repository Repository
+}
+
+func NewCreateCategoryHandler(repository Repository) *CreateCategoryHandler {
+	return &CreateCategoryHandler{
+		repository: repository,
+	}
+}
+
+type CreateCategoryRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description,omitempty"`
+	ParentID    *string `json:"parentId,omitempty" validate:"omitempty,uuid"`
+	Status      string  `json:"status,omitempty" validate:"omitempty,oneof=active inactive"`
+}
+
+type CreateCategoryResponse struct {
+	Category domain.Category `json:"category"`
+}
+
+func (h CreateCategoryHandler) Handle(ctx context.Context, req *CreateCategoryRequest) (*CreateCategoryResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"category.store.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.store.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	category := domain.Category{
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+		Status:      domain.CategoryStatusActive,
+	}
+	if req.Status != "" {
+		category.Status = req.Status
+	}
+
+	if req.ParentID != nil {
+		parent, err := h.repository.GetCategoryByID(ctx, *req.ParentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, httperror.NotFound(
+					"category.store.parent_not_found",
+					"Parent category not found",
+					nil,
+				)
+			}
+
+			return nil, httperror.InternalServerError(
+				"category.store.failed",
+				"Failed to retrieve parent category",
+				nil,
+			)
+		}
+
+		if parent.IsArchived() {
+			return nil, httperror.Conflict(
+				"category.store.parent_archived",
+				"Categories cannot be added under an archived category",
+				nil,
+			)
+		}
+	}
+
+	created, err := h.repository.CreateCategory(ctx, category)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.store.failed",
+			"Failed to create category",
+			nil,
+		)
+	}
+
+	return &CreateCategoryResponse{
+		Category: created,
+	}, nil
+}
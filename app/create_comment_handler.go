@@ -6,20 +6,24 @@ import (
 	"auction/pkg/httperror"
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/go-playground/validator/v10"
-	"go.uber.org/zap"
 )
 
 type CreateCommentHandler struct {
 	repository     Repository
 	eventPublisher events.Publisher
+	moderator      CommentModerator
 }
 
-func NewCreateCommentHandler(repository Repository, eventPublisher events.Publisher) *CreateCommentHandler {
+// NewCreateCommentHandler creates the handler. New comments are reviewed by
+// moderator; nil publishes them right away.
+func NewCreateCommentHandler(repository Repository, eventPublisher events.Publisher, moderator CommentModerator) *CreateCommentHandler {
 	return &CreateCommentHandler{
 		repository:     repository,
 		eventPublisher: eventPublisher,
+		moderator:      moderator,
 	}
 }
 
@@ -27,6 +31,7 @@ type CreateCommentRequest struct {
 	ItemID   string  `params:"id" validate:"required,uuid"`
 	Comment  string  `json:"content" validate:"required"`
 	ParentID *string `json:"parentId,omitempty" validate:"omitempty,uuid"`
+	Kind     string  `json:"kind,omitempty" validate:"omitempty,oneof=comment question answer"` // comment by default
 }
 
 type CreateCommentResponse struct {
@@ -63,24 +68,85 @@ func (c *CreateCommentHandler) Handle(ctx context.Context, req *CreateCommentReq
 
 	userID := ctx.Value("UserID").(string)
 
-	comment, err := c.repository.CreateComment(ctx, item.ID, req.Comment, userID, req.ParentID)
+	kind := domain.CommentKindComment
+	if req.Kind != "" {
+		kind = domain.CommentKind(req.Kind)
+	}
+
+	var parent *domain.ItemComment
+	if req.ParentID != nil {
+		comment, err := c.repository.GetCommentByID(ctx, *req.ParentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, httperror.NotFound("comments.create.parent_not_found", "Parent comment not found", nil)
+			}
+
+			return nil, httperror.InternalServerError("comments.create.internal_error", "Failed to get parent comment", nil)
+		}
+		parent = &comment
+
+		// Replies stay on the item of the thread they answer
+		if parent.ItemID != item.ID {
+			return nil, httperror.UnprocessableEntity("comments.create.parent_item_mismatch", "Parent comment belongs to another item", nil)
+		}
+
+		if !parent.IsVisible() {
+			return nil, httperror.Conflict("comments.create.parent_not_visible", "Hidden, pending or deleted comments cannot be replied to", nil)
+		}
+	}
+
+	if err := checkCommentKind(kind, item, parent, userID); err != nil {
+		return nil, err
+	}
+
+	comment := domain.ItemComment{
+		ItemID:   item.ID,
+		Content:  req.Comment,
+		UserID:   userID,
+		ParentID: req.ParentID,
+		Kind:     kind,
+	}
+
+	comment.Status, err = reviewComment(ctx, c.moderator, "comments.create", comment)
 	if err != nil {
-		return nil, httperror.InternalServerError("comments.create.internal_error", "Failed to create comment", err)
+		return nil, err
 	}
 
-	c.publishEvent(ctx, comment)
+	err = c.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		comment, err = c.repository.CreateComment(ctx, comment)
+		if err != nil {
+			return err
+		}
+
+		if err := c.publishEvent(ctx, comment); err != nil {
+			return err
+		}
+
+		// Held answers are announced once a moderator releases them
+		if !comment.IsAnswer() || !comment.IsVisible() {
+			return nil
+		}
+
+		return publishQuestionAnswered(ctx, c.eventPublisher, *parent, comment)
+	})
+	if err != nil {
+		return nil, httperror.InternalServerError("comments.create.internal_error", "Failed to create comment", err)
+	}
 
 	return &CreateCommentResponse{
 		Comment: comment,
 	}, nil
 }
 
-func (e CreateCommentHandler) publishEvent(ctx context.Context, comment domain.ItemComment) {
+func (e CreateCommentHandler) publishEvent(ctx context.Context, comment domain.ItemComment) error {
 	eventPayload := events.ItemCommentCreatedPayload{
 		ID:        comment.ID,
 		ItemID:    comment.ItemID,
 		AuthorID:  comment.UserID,
 		Content:   comment.Content,
+		Kind:      string(comment.Kind),
+		Status:    string(comment.Status),
 		CreatedAt: comment.CreatedAt,
 	}
 
@@ -98,9 +164,8 @@ func (e CreateCommentHandler) publishEvent(ctx context.Context, comment domain.I
 	)
 
 	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-		zap.L().Error("Failed to publish item.comment.created event",
-			zap.String("commentID", comment.ID),
-			zap.Error(err),
-		)
+		return fmt.Errorf("failed to publish item.comment.created event: %w", err)
 	}
+
+	return nil
 }
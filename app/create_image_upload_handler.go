@@ -0,0 +1,143 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/aws"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CreateImageUploadHandler struct {
+	repository Repository
+	maxImages  int
+	urlTTL     time.Duration
+	maxBytes   int64
+}
+
+// NewCreateImageUploadHandler creates the handler. Upload URLs are valid for
+// urlTTL and accept images of up to maxBytes; items hold at most maxImages
+// images, 0 means no limit.
+func NewCreateImageUploadHandler(repository Repository, maxImages int, urlTTL time.Duration, maxBytes int64) *CreateImageUploadHandler {
+	return &CreateImageUploadHandler{
+		repository: repository,
+		maxImages:  maxImages,
+		urlTTL:     urlTTL,
+		maxBytes:   maxBytes,
+	}
+}
+
+type CreateImageUploadRequest struct {
+	ItemID      string `params:"itemId" validate:"required,uuid"`
+	ContentType string `json:"contentType" validate:"required,oneof=image/png image/jpeg image/jpg"`
+	Size        int64  `json:"size" validate:"required,min=1"` // Bytes of the file, signed into the URL
+}
+
+type CreateImageUploadResponse struct {
+	Upload    domain.ItemImageUpload `json:"upload"`
+	UploadURL string                 `json:"upload_url"`
+	Method    string                 `json:"method"`
+	Headers   map[string]string      `json:"headers"` // Sent with the upload so the object is stored with its type
+	MaxBytes  int64                  `json:"max_bytes"`
+}
+
+// Handle reserves an image slot of one of the user's items and returns a
+// presigned URL the image is PUT to. The image is added once the upload is
+// completed.
+func (h *CreateImageUploadHandler) Handle(ctx context.Context, req *CreateImageUploadRequest) (*CreateImageUploadResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item_images.upload_url.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item_images.upload_url.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	if h.maxBytes > 0 && req.Size > h.maxBytes {
+		return nil, httperror.UnprocessableEntity("item_images.upload_url.file_too_large", "The image is too large",
+			map[string]int64{
+				"size":      req.Size,
+				"max_bytes": h.maxBytes,
+			})
+	}
+
+	userID := ctx.Value("UserID").(string)
+
+	item, err := h.repository.GetItem(ctx, req.ItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound("item_images.upload_url.not_found", "Item not found", nil)
+		}
+
+		return nil, httperror.InternalServerError("item_images.upload_url.failed", "Failed to get item", nil)
+	}
+	if item.SellerID != userID {
+		return nil, httperror.Forbidden("item_images.upload_url.forbidden", "You are not authorized to upload images for this item", nil)
+	}
+
+	id := uuid.New().String()
+	upload := domain.ItemImageUpload{
+		ID:          id,
+		ItemID:      item.ID,
+		ObjectKey:   fmt.Sprintf("uploads/%s/%s%s", item.ID, id, getExtensionFromContentType(req.ContentType)),
+		ContentType: req.ContentType,
+		ExpiresAt:   time.Now().UTC().Add(h.urlTTL),
+	}
+
+	uploadURL, err := aws.NewS3Bucket().PresignUpload(ctx, upload.ObjectKey, upload.ContentType, req.Size, h.urlTTL)
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.upload_url.presign_failed", "Failed to create upload URL", nil)
+	}
+
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockItemImages(ctx, item.ID); err != nil {
+			return err
+		}
+
+		count, err := itemImageSlots(ctx, h.repository, item.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkImageLimit("item_images.upload_url.too_many_images", h.maxImages, count, 1); err != nil {
+			return err
+		}
+
+		upload, err = h.repository.CreateImageUpload(ctx, upload)
+
+		return err
+	})
+	if err != nil {
+		var httpErr *httperror.Error
+		if errors.As(err, &httpErr) {
+			return nil, httpErr
+		}
+
+		return nil, httperror.InternalServerError("item_images.upload_url.failed", "Failed to create upload", nil)
+	}
+
+	return &CreateImageUploadResponse{
+		Upload:    upload,
+		UploadURL: uploadURL,
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": upload.ContentType, "Content-Length": strconv.FormatInt(req.Size, 10)},
+		MaxBytes:  h.maxBytes,
+	}, nil
+}
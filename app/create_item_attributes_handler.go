@@ -5,9 +5,7 @@ import (
 	"auction/pkg/events"
 	"auction/pkg/httperror"
 	"context"
-	"time"
-
-	"go.uber.org/zap"
+	"fmt"
 )
 
 type CreateItemAttributesHandler struct {
@@ -16,7 +14,7 @@ type CreateItemAttributesHandler struct {
 }
 
 type AttributeKeyValue struct {
-	Key   string `json:"key"`
+	Key   string `json:"key" validate:"required,max=100"`
 	Value string `json:"value"`
 }
 
@@ -51,12 +49,41 @@ func (r *CreateItemAttributesHandler) Handle(ctx context.Context, req *CreateIte
 		}
 	}
 
-	createdAttributes, err := r.repository.CreateItemAttributes(ctx, attributes)
+	categoryIDs := make([]string, len(item.Categories))
+	for i, category := range item.Categories {
+		categoryIDs[i] = category.ID
+	}
+
+	schema, err := loadAttributeSchema(ctx, r.repository, categoryIDs)
 	if err != nil {
-		return nil, httperror.InternalServerError("create_item.store.create_failed", "Failed to create item attributes", nil)
+		return nil, httperror.InternalServerError("create_item.store.create_failed", "Failed to get category attribute schemas", nil)
+	}
+
+	existing, err := r.repository.GetItemAttributes(ctx, item.ID)
+	if err != nil {
+		return nil, httperror.InternalServerError("create_item.store.create_failed", "Failed to get item attributes", nil)
 	}
 
-	r.publishEvent(ctx, createdAttributes)
+	// Attributes are added to the ones the item has, so required keys are only
+	// enforced when the item is created
+	attributes, violations := schema.Validate(attributes, existing, false)
+	if len(violations) > 0 {
+		return nil, invalidAttributes("create_item.store.invalid_attributes", violations)
+	}
+
+	var createdAttributes []domain.ItemAttribute
+	err = r.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdAttributes, err = r.repository.CreateItemAttributes(ctx, attributes)
+		if err != nil {
+			return err
+		}
+
+		return r.publishEvent(ctx, createdAttributes)
+	})
+	if err != nil {
+		return nil, httperror.InternalServerError("create_item.store.create_failed", "Failed to create item attributes", nil)
+	}
 
 	return &CreateItemAttributesResponse{
 		Attributes: createdAttributes,
@@ -65,39 +92,30 @@ func (r *CreateItemAttributesHandler) Handle(ctx context.Context, req *CreateIte
 
 func (r CreateItemAttributesHandler) publishEvent(ctx context.Context, attributes []domain.ItemAttribute) error {
 	for _, attribute := range attributes {
-		go func() {
-			eventPayload := events.ItemAttributeCreatedPayload{
-				ID:        attribute.ID,
-				ItemID:    attribute.ItemID,
-				Key:       attribute.Key,
-				Value:     attribute.Value,
-				CreatedAt: attribute.CreatedAt,
-			}
-
-			headers := events.Headers{
-				TraceID:       events.GenerateTraceID(),
-				CorrelationID: events.GenerateCorrelationID(),
-				Service:       "auction",
-			}
-
-			event := events.NewEvent(
-				events.ItemAttributeCreatedEvent,
-				events.EventVersionV1,
-				eventPayload,
-				headers,
-			)
-
-			publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-			defer cancel()
-
-			if err := r.publisher.Publish(publishCtx, events.ItemExchange, event, headers); err != nil {
-				zap.L().Error(
-					"Failed to publish item.attribute.created event",
-					zap.String("attributeID", attribute.ID),
-					zap.Error(err),
-				)
-			}
-		}()
+		eventPayload := events.ItemAttributeCreatedPayload{
+			ID:        attribute.ID,
+			ItemID:    attribute.ItemID,
+			Key:       attribute.Key,
+			Value:     attribute.Value,
+			CreatedAt: attribute.CreatedAt,
+		}
+
+		headers := events.Headers{
+			TraceID:       events.GenerateTraceID(),
+			CorrelationID: events.GenerateCorrelationID(),
+			Service:       "auction",
+		}
+
+		event := events.NewEvent(
+			events.ItemAttributeCreatedEvent,
+			events.EventVersionV1,
+			eventPayload,
+			headers,
+		)
+
+		if err := r.publisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+			return fmt.Errorf("failed to publish item.attribute.created event: %w", err)
+		}
 	}
 
 	return nil
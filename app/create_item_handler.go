@@ -5,44 +5,51 @@ import (
 	"auction/pkg/events"
 	"auction/pkg/httperror"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
-	"go.uber.org/zap"
 )
 
 type CreateItemHandler struct {
 	repository     Repository
 	eventPublisher events.Publisher
+	languages      []string
 }
 
 type CreateItemRequest struct {
-	Name                      string           `json:"name" validate:"required" db:"name"`
-	Description               *string          `json:"description" db:"description"`
-	CurrencyCode              string           `json:"currencyCode" validate:"required,iso4217" db:"currency_code"`
-	SellerID                  string           `json:"sellerID,omitempty" db:"seller_id"`
-	StartPrice                decimal.Decimal  `json:"startPrice" validate:"required" db:"start_price"`
-	BidIncrement              *decimal.Decimal `json:"bidIncrement" validate:"required" db:"bid_increment"`
-	ReservePrice              *decimal.Decimal `json:"reservePrice,omitempty" db:"reserve_price"`
-	BuyoutPrice               *decimal.Decimal `json:"buyoutPrice,omitempty" db:"buyout_price"`
-	EndPrice                  *decimal.Decimal `json:"endPrice,omitempty" db:"end_price"`
-	StartDate                 time.Time        `json:"startDate" validate:"required" db:"start_date"`
-	EndDate                   time.Time        `json:"endDate" validate:"required,gtfield=StartDate" db:"end_date"`
-	Status                    string           `json:"status,omitempty" validate:"required,oneof=draft active sold cancelled" db:"status"`
-	CategoryIDs               []string         `json:"categoryIds,omitempty"`
-	ExtensionThresholdMinutes *int             `json:"extensionThresholdMinutes,omitempty" db:"extension_threshold_minutes"`
-	ExtensionDurationMinutes  *int             `json:"extensionDurationMinutes,omitempty" db:"extension_duration_minutes"`
+	Name                      string              `json:"name" validate:"required" db:"name"`
+	Description               *string             `json:"description" db:"description"`
+	CurrencyCode              string              `json:"currencyCode" validate:"required,iso4217" db:"currency_code"`
+	Language                  *string             `json:"language,omitempty" db:"language"` // One of the search languages, the first one by default
+	SellerID                  string              `json:"sellerID,omitempty" db:"seller_id"`
+	StartPrice                decimal.Decimal     `json:"startPrice" validate:"required" db:"start_price"`
+	BidIncrement              *decimal.Decimal    `json:"bidIncrement" validate:"required" db:"bid_increment"`
+	ReservePrice              *decimal.Decimal    `json:"reservePrice,omitempty" db:"reserve_price"`
+	BuyoutPrice               *decimal.Decimal    `json:"buyoutPrice,omitempty" db:"buyout_price"`
+	EndPrice                  *decimal.Decimal    `json:"endPrice,omitempty" db:"end_price"`
+	StartDate                 time.Time           `json:"startDate" validate:"required" db:"start_date"`
+	EndDate                   time.Time           `json:"endDate" validate:"required,gtfield=StartDate" db:"end_date"`
+	Status                    domain.ItemStatus   `json:"status,omitempty" validate:"required,oneof=draft scheduled active" db:"status"`
+	CategoryIDs               []string            `json:"categoryIds,omitempty" validate:"omitempty,unique,dive,uuid"`
+	Attributes                []AttributeKeyValue `json:"attributes,omitempty"` // Validated against the attribute schemas of the categories
+	ExtensionThresholdMinutes *int                `json:"extensionThresholdMinutes,omitempty" db:"extension_threshold_minutes"`
+	ExtensionDurationMinutes  *int                `json:"extensionDurationMinutes,omitempty" db:"extension_duration_minutes"`
 }
 
 type CreateItemResponse struct {
-	Item domain.Item `json:"item"`
+	Item       domain.Item            `json:"item"`
+	Attributes []domain.ItemAttribute `json:"attributes"`
 }
 
-func NewCreateItemHandler(repository Repository, eventPublisher events.Publisher) *CreateItemHandler {
+// NewCreateItemHandler creates the handler. languages are the text search
+// configurations items may be written in; the first one is the default.
+func NewCreateItemHandler(repository Repository, eventPublisher events.Publisher, languages []string) *CreateItemHandler {
 	return &CreateItemHandler{
 		repository:     repository,
 		eventPublisher: eventPublisher,
+		languages:      languages,
 	}
 }
 
@@ -65,10 +72,84 @@ func (e CreateItemHandler) Handle(ctx context.Context, req *CreateItemRequest) (
 		)
 	}
 
+	language, err := itemLanguage(req.Language, e.languages)
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"item.create.unsupported_language",
+			"Unsupported item language",
+			map[string]any{"supportedLanguages": e.languages},
+		)
+	}
+	req.Language = language
+
+	// Published items go live at their start date: until then they stay scheduled
+	if req.Status != domain.ItemStatusDraft {
+		now := time.Now().UTC()
+		if !req.EndDate.After(now) {
+			return nil, httperror.BadRequest(
+				"item.create.end_date_passed",
+				"End date must be in the future",
+				nil,
+			)
+		}
+
+		req.Status = domain.PublishStatus(req.StartDate, now)
+	}
+
+	if err := checkAssignableCategories(ctx, e.repository, req.CategoryIDs); err != nil {
+		if httpErr := categoryAssignmentFailed("item.create.invalid_categories", err); httpErr != nil {
+			return nil, httpErr
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.create.create_failed",
+			"An error occurred while creating the item",
+			nil,
+		)
+	}
+
+	schema, err := loadAttributeSchema(ctx, e.repository, req.CategoryIDs)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"item.create.create_failed",
+			"An error occurred while creating the item",
+			nil,
+		)
+	}
+
+	attributes := make([]domain.ItemAttribute, len(req.Attributes))
+	for i, attribute := range req.Attributes {
+		attributes[i] = domain.ItemAttribute{Key: attribute.Key, Value: attribute.Value}
+	}
+
+	attributes, violations := schema.Validate(attributes, nil, true)
+	if len(violations) > 0 {
+		return nil, invalidAttributes("item.create.invalid_attributes", violations)
+	}
+
 	userID := ctx.Value("UserID").(string)
 	req.SellerID = userID
 
-	item, err := e.repository.Create(ctx, req)
+	var item domain.Item
+	createdAttributes := []domain.ItemAttribute{}
+	err = e.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		item, err = e.repository.Create(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for i := range attributes {
+			attributes[i].ItemID = item.ID
+		}
+
+		createdAttributes, err = e.repository.CreateItemAttributes(ctx, attributes)
+		if err != nil {
+			return err
+		}
+
+		return e.publishEvent(ctx, item)
+	})
 	if err != nil {
 		return nil, httperror.InternalServerError(
 			"item.create.create_failed",
@@ -77,50 +158,50 @@ func (e CreateItemHandler) Handle(ctx context.Context, req *CreateItemRequest) (
 		)
 	}
 
-	e.publishEvent(ctx, item)
-
 	return &CreateItemResponse{
-		Item: item,
+		Item:       item,
+		Attributes: createdAttributes,
 	}, nil
 }
 
-func (e CreateItemHandler) publishEvent(ctx context.Context, item domain.Item) {
-	if e.eventPublisher != nil {
-		eventPayload := events.ItemCreatedPayload{
-			ID:           item.ID,
-			Name:         item.Name,
-			Description:  item.Description,
-			SellerID:     item.SellerID,
-			CurrencyCode: item.CurrencyCode,
-			StartPrice:   item.StartPrice,
-			CurrentPrice: item.CurrentPrice,
-			BidIncrement: item.BidIncrement,
-			ReservePrice: item.ReservePrice,
-			BuyoutPrice:  item.BuyoutPrice,
-			StartDate:    item.StartDate,
-			EndDate:      item.EndDate,
-			Status:       item.Status,
-			CreatedAt:    item.CreatedAt,
-		}
+func (e CreateItemHandler) publishEvent(ctx context.Context, item domain.Item) error {
+	if e.eventPublisher == nil {
+		return nil
+	}
 
-		headers := events.Headers{
-			TraceID:       events.GenerateTraceID(),
-			CorrelationID: events.GenerateCorrelationID(),
-			Service:       "auction",
-		}
+	eventPayload := events.ItemCreatedPayload{
+		ID:           item.ID,
+		Name:         item.Name,
+		Description:  item.Description,
+		SellerID:     item.SellerID,
+		CurrencyCode: item.CurrencyCode,
+		StartPrice:   item.StartPrice,
+		CurrentPrice: item.CurrentPrice,
+		BidIncrement: item.BidIncrement,
+		ReservePrice: item.ReservePrice,
+		BuyoutPrice:  item.BuyoutPrice,
+		StartDate:    item.StartDate,
+		EndDate:      item.EndDate,
+		Status:       string(item.Status),
+		CreatedAt:    item.CreatedAt,
+	}
 
-		event := events.NewEvent(
-			events.ItemCreatedEvent,
-			events.EventVersionV1,
-			eventPayload,
-			headers,
-		)
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
 
-		if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-			zap.L().Error("Failed to publish item.created event",
-				zap.String("itemId", item.ID),
-				zap.Error(err),
-			)
-		}
+	event := events.NewEvent(
+		events.ItemCreatedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.created event: %w", err)
 	}
+
+	return nil
 }
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var errCategoryInUse = errors.New("category has subcategories or items")
+
+type DeleteCategoryHandler struct {
+	repository Repository
+}
+
+func NewDeleteCategoryHandler(repository Repository) *DeleteCategoryHandler {
+	return &DeleteCategoryHandler{
+		repository: repository,
+	}
+}
+
+type DeleteCategoryRequest struct {
+	ID string `params:"id" validate:"required,uuid"`
+}
+
+type DeleteCategoryResponse struct {
+}
+
+// Handle deletes a category that has no subcategories and no items. Categories
+// still in use have to be emptied first, or archived instead.
+func (h DeleteCategoryHandler) Handle(ctx context.Context, req *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"category.destroy.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.destroy.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	var children, items int
+	err := h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Keeps categories from being moved under this one while it is deleted
+		if err := h.repository.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		children, items, err = h.repository.CountCategoryReferences(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if children > 0 || items > 0 {
+			return errCategoryInUse
+		}
+
+		return h.repository.DeleteCategory(ctx, req.ID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, httperror.NotFound(
+				"category.destroy.not_found",
+				"Category not found",
+				nil,
+			)
+		case errors.Is(err, errCategoryInUse):
+			return nil, httperror.Conflict(
+				"category.destroy.in_use",
+				"Category has subcategories or items, archive it instead",
+				map[string]any{"subcategories": children, "items": items},
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.destroy.failed",
+			"Failed to delete category",
+			nil,
+		)
+	}
+
+	return nil, httperror.NoContent(
+		"category.destroy.success",
+		"Category deleted successfully",
+		nil,
+	)
+}
@@ -5,9 +5,10 @@ import (
 	"auction/pkg/events"
 	"auction/pkg/httperror"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"time"
-
-	"go.uber.org/zap"
 )
 
 type DeleteCommentHandler struct {
@@ -30,34 +31,50 @@ func NewDeleteCommentHandler(repository Repository, eventPublisher events.Publis
 	}
 }
 
+// Handle deletes a comment of the current user. The comment stays in its
+// thread as a tombstone, so its replies are kept.
 func (h *DeleteCommentHandler) Handle(ctx context.Context, req *DeleteCommentRequest) (*DeleteCommentResponse, error) {
 	comment, err := h.repository.GetCommentByID(ctx, req.CommentID)
-	if err != nil {
-		return nil, err
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError("comment.destroy.failed", "Failed to get comment", nil)
+	}
+	if err != nil || comment.ItemID != req.ItemID || comment.IsDeleted() {
+		return nil, httperror.NotFound("comment.destroy.not_found", "Comment not found", nil)
 	}
 
 	userID := ctx.Value("UserID").(string)
 
 	if comment.UserID != userID {
-		return nil, httperror.Forbidden("comment.destroy", "Cannot delete comment with parent", nil)
+		return nil, httperror.Forbidden("comment.destroy", "Only the author can delete a comment", nil)
 	}
 
-	err = h.repository.DeleteComment(ctx, req.CommentID)
+	previous := comment.Status
+	comment.Delete(time.Now().UTC())
+
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := h.repository.UpdateCommentStatus(ctx, comment, previous); err != nil {
+			return err
+		}
+
+		return h.publishEvent(ctx, comment)
+	})
 	if err != nil {
-		return nil, err
-	}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.Conflict("comment.destroy.conflict", "The comment was moderated meanwhile, retry", nil)
+		}
 
-	h.publishEvent(ctx, comment)
+		return nil, httperror.InternalServerError("comment.destroy.failed", "Failed to delete comment", nil)
+	}
 
 	return nil, httperror.NoContent("comment.destroy.success", "Comment deleted", nil)
 }
 
-func (e DeleteCommentHandler) publishEvent(ctx context.Context, comment domain.ItemComment) {
+func (e DeleteCommentHandler) publishEvent(ctx context.Context, comment domain.ItemComment) error {
 	eventPayload := events.ItemCommentDeletedPayload{
 		ID:        comment.ID,
 		ItemID:    comment.ItemID,
 		AuthorID:  comment.UserID,
-		DeletedAt: time.Now(),
+		DeletedAt: *comment.DeletedAt,
 	}
 
 	headers := events.Headers{
@@ -74,9 +91,8 @@ func (e DeleteCommentHandler) publishEvent(ctx context.Context, comment domain.I
 	)
 
 	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-		zap.L().Error("Failed to publish item.comment.created event",
-			zap.String("commentID", comment.ID),
-			zap.Error(err),
-		)
+		return fmt.Errorf("failed to publish item.comment.deleted event: %w", err)
 	}
+
+	return nil
 }
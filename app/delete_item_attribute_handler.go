@@ -6,9 +6,8 @@ import (
 	"auction/pkg/httperror"
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
-
-	"go.uber.org/zap"
 )
 
 type DeleteItemAttributeHandler struct {
@@ -43,7 +42,13 @@ func (r *DeleteItemAttributeHandler) Handle(ctx context.Context, req *DeleteItem
 		return nil, httperror.Forbidden("delete_item.destroy.forbidden", "You are not authorized to delete this item", nil)
 	}
 
-	err = r.repository.DeleteItemAttribute(ctx, req.ItemID, req.AttributeID)
+	err = r.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.repository.DeleteItemAttribute(ctx, req.ItemID, req.AttributeID); err != nil {
+			return err
+		}
+
+		return r.publishEvent(ctx, item, req.AttributeID)
+	})
 	if err != nil {
 		return nil, httperror.InternalServerError("delete_item.destroy.server_errror", "Internal server error", nil)
 	}
@@ -71,15 +76,8 @@ func (r *DeleteItemAttributeHandler) publishEvent(ctx context.Context, item doma
 		headers,
 	)
 
-	publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := r.publisher.Publish(publishCtx, events.ItemExchange, event, headers); err != nil {
-		zap.L().Error(
-			"Failed to publish item.attribute.deleted event",
-			zap.String("attributeID", attributeID),
-			zap.Error(err),
-		)
+	if err := r.publisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.attribute.deleted event: %w", err)
 	}
 
 	return nil
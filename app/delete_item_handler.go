@@ -6,9 +6,8 @@ import (
 	"auction/pkg/httperror"
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
-
-	"go.uber.org/zap"
 )
 
 type DeleteItemHandler struct {
@@ -49,7 +48,13 @@ func (h DeleteItemHandler) Handle(ctx context.Context, req *DeleteItemRequest) (
 		)
 	}
 
-	err = h.repository.DeleteItem(ctx, req.ItemID, userID)
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.DeleteItem(ctx, req.ItemID, userID); err != nil {
+			return err
+		}
+
+		return h.publishEvent(ctx, item)
+	})
 	if err != nil {
 		return nil, httperror.InternalServerError(
 			"item.destroy.failed",
@@ -58,8 +63,6 @@ func (h DeleteItemHandler) Handle(ctx context.Context, req *DeleteItemRequest) (
 		)
 	}
 
-	h.publishEvent(ctx, item)
-
 	return nil, httperror.NoContent(
 		"item.destroy.success",
 		"Item deleted successfully",
@@ -67,32 +70,33 @@ func (h DeleteItemHandler) Handle(ctx context.Context, req *DeleteItemRequest) (
 	)
 }
 
-func (h DeleteItemHandler) publishEvent(ctx context.Context, item domain.Item) {
-	if h.eventPublisher != nil {
-		eventPayload := events.ItemDeletedPayload{
-			ID:        item.ID,
-			SellerID:  item.SellerID,
-			DeletedAt: time.Now().UTC(),
-		}
+func (h DeleteItemHandler) publishEvent(ctx context.Context, item domain.Item) error {
+	if h.eventPublisher == nil {
+		return nil
+	}
 
-		headers := events.Headers{
-			TraceID:       events.GenerateTraceID(),
-			CorrelationID: events.GenerateCorrelationID(),
-			Service:       "auction",
-		}
+	eventPayload := events.ItemDeletedPayload{
+		ID:        item.ID,
+		SellerID:  item.SellerID,
+		DeletedAt: time.Now().UTC(),
+	}
 
-		event := events.NewEvent(
-			events.ItemDeletedEvent,
-			events.EventVersionV1,
-			eventPayload,
-			headers,
-		)
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
 
-		if err := h.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-			zap.L().Error("Failed to publish item.deleted event",
-				zap.String("itemId", item.ID),
-				zap.Error(err),
-			)
-		}
+	event := events.NewEvent(
+		events.ItemDeletedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.deleted event: %w", err)
 	}
+
+	return nil
 }
@@ -3,19 +3,15 @@ package app
 import (
 	"auction/domain"
 	"auction/pkg/aws"
-	"auction/pkg/config"
 	"auction/pkg/events"
 	"auction/pkg/httperror"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
-
-	"go.uber.org/zap"
 )
 
-var appConfig = config.Read()
-
 type DeleteItemImageHandler struct {
 	repository     Repository
 	eventPublisher events.Publisher
@@ -52,29 +48,71 @@ func (h *DeleteItemImageHandler) Handle(ctx context.Context, req *DeleteItemImag
 		return nil, httperror.NotFound("delete_item_image.destroy.not_found", "Image not found.", nil)
 	}
 
-	bucket := aws.NewS3Bucket()
-	key := extractImageKey(image.ImageURL)
-	err = bucket.Delete(key)
+	// Objects are deleted once the row is gone, holding the images lock keeps
+	// the worker from storing variants of the image in the meantime
+	keys := []string{aws.ObjectKey(image.ImageURL)}
+
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockItemImages(ctx, req.ItemID); err != nil {
+			return err
+		}
+
+		// Whether it is the primary image may have changed since it was read
+		image, err := h.repository.GetItemImage(ctx, req.ItemID, req.ImageID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted by a concurrent request, which published the events
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		variants, err := h.repository.GetItemImageVariants(ctx, []string{image.ID})
+		if err != nil {
+			return err
+		}
+		for _, variant := range variants {
+			keys = append(keys, aws.ObjectKey(variant.ImageURL))
+		}
+
+		if err := h.repository.DeleteItemImage(ctx, req.ItemID, req.ImageID); err != nil {
+			return err
+		}
+
+		if err := h.publishEvent(ctx, image); err != nil {
+			return err
+		}
+
+		if !image.IsPrimary {
+			return nil
+		}
+
+		// The next image becomes the cover unless this was the last one
+		primary, err := h.repository.GetPrimaryItemImage(ctx, req.ItemID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return publishPrimaryImageChanged(ctx, h.eventPublisher, item, primary, &image.ID)
+	})
 	if err != nil {
 		return nil, httperror.InternalServerError("delete_item_image.destroy.failed", "Failed to delete image.", err)
 	}
 
-	err = h.repository.DeleteItemImage(ctx, req.ItemID, req.ImageID)
-	if err != nil {
-		return nil, httperror.InternalServerError("delete_item_image.destroy.failed", "Failed to delete image.", err)
+	bucket := aws.NewS3Bucket()
+	for _, key := range keys {
+		if err := bucket.Delete(key); err != nil {
+			return nil, httperror.InternalServerError("delete_item_image.destroy.failed", "Failed to delete image.", err)
+		}
 	}
 
-	h.publishEvent(ctx, image)
-
 	return &DeleteItemImageResponse{}, httperror.NoContent("delete_item_image.destroy.success", "Image deleted successfully.", nil)
 }
 
-func extractImageKey(imageUrl string) string {
-	url := fmt.Sprintf("%s/%s/", appConfig.AWSEndpoint, appConfig.AWSBucket)
-	return strings.Replace(imageUrl, url, "", 1)
-}
-
-func (e DeleteItemImageHandler) publishEvent(ctx context.Context, image domain.ItemImage) {
+func (e DeleteItemImageHandler) publishEvent(ctx context.Context, image domain.ItemImage) error {
 	eventPayload := events.ItemImageDeletedPayload{
 		ID:        image.ID,
 		ItemID:    image.ItemID,
@@ -96,9 +134,8 @@ func (e DeleteItemImageHandler) publishEvent(ctx context.Context, image domain.I
 	)
 
 	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-		zap.L().Error("Failed to publish item.image.deleted event",
-			zap.String("imageID", image.ID),
-			zap.Error(err),
-		)
+		return fmt.Errorf("failed to publish item.image.deleted event: %w", err)
 	}
+
+	return nil
 }
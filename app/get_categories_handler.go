@@ -17,21 +17,24 @@ func NewGetCategoriesHandler(repository Repository) *GetCategoriesHandler {
 }
 
 type GetCategoriesRequest struct {
-	Page     int `query:"page"`
-	PageSize int `query:"pageSize"`
+	Page         int    `query:"page"`
+	PageSize     int    `query:"pageSize"`
+	Cursor       string `query:"cursor"`       // nextCursor or prevCursor of a previous response; replaces page
+	IncludeTotal bool   `query:"includeTotal"` // Count the total with a cursor; page requests always do
 }
 
 type GetCategoriesResponse struct {
 	Categories []domain.Category `json:"categories"`
-	Page       int               `json:"page"`
-	PageSize   int               `json:"pageSize"`
-	TotalItems int               `json:"totalItems"`
-	TotalPages int               `json:"totalPages"`
+	Pagination
 }
 
 func (h GetCategoriesHandler) Handle(ctx context.Context, req *GetCategoriesRequest) (*GetCategoriesResponse, error) {
 	page := max(req.Page, 1)
-	pageSize := max(req.PageSize, 10)
+	pageSize := clampPageSize(req.PageSize)
+
+	if req.Cursor != "" {
+		return h.handleCursor(ctx, req, pageSize)
+	}
 
 	offset := (page - 1) * pageSize
 
@@ -53,13 +56,52 @@ func (h GetCategoriesHandler) Handle(ctx context.Context, req *GetCategoriesRequ
 		)
 	}
 
-	totalPages := (totalItems + pageSize - 1) / pageSize
+	return &GetCategoriesResponse{
+		Categories: categories,
+		Pagination: offsetPagination(categories, page, pageSize, totalItems, "", categoryCursorKey),
+	}, nil
+}
+
+func (h GetCategoriesHandler) handleCursor(ctx context.Context, req *GetCategoriesRequest, pageSize int) (*GetCategoriesResponse, error) {
+	cursor, err := DecodeCursor(req.Cursor, "", CursorTime)
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"category.index.invalid_cursor",
+			"Invalid cursor",
+			nil,
+		)
+	}
+
+	pageRequest := PageRequest{Cursor: cursor, Limit: pageSize}
+
+	categories, more, err := h.repository.GetCategoriesPage(ctx, pageRequest)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.index.failed",
+			"Failed to retrieve categories",
+			nil,
+		)
+	}
+
+	var totalItems *int
+	if req.IncludeTotal {
+		count, err := h.repository.CountCategories(ctx)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"category.count_categories.failed",
+				"Failed to count categories",
+				nil,
+			)
+		}
+		totalItems = &count
+	}
 
 	return &GetCategoriesResponse{
 		Categories: categories,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		Pagination: cursorPagination(categories, pageRequest, more, totalItems, "", categoryCursorKey),
 	}, nil
 }
+
+func categoryCursorKey(category domain.Category) (string, string) {
+	return formatCursorTime(category.CreatedAt), category.ID
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type GetCategoryAttributesHandler struct {
+	repository Repository
+}
+
+func NewGetCategoryAttributesHandler(repository Repository) *GetCategoryAttributesHandler {
+	return &GetCategoryAttributesHandler{
+		repository: repository,
+	}
+}
+
+type GetCategoryAttributesRequest struct {
+	ID string `params:"id"`
+}
+
+type GetCategoryAttributesResponse struct {
+	Attributes []domain.CategoryAttribute `json:"attributes"`
+}
+
+func (h GetCategoryAttributesHandler) Handle(ctx context.Context, req *GetCategoryAttributesRequest) (*GetCategoryAttributesResponse, error) {
+	category, err := h.repository.GetCategoryByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.attributes.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.attributes.failed",
+			"Failed to get category",
+			nil,
+		)
+	}
+
+	attributes, err := h.repository.GetCategoryAttributes(ctx, []string{category.ID})
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.attributes.failed",
+			"Failed to get category attributes",
+			nil,
+		)
+	}
+
+	return &GetCategoryAttributesResponse{
+		Attributes: attributes,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+)
+
+type GetCategoryBreadcrumbsHandler struct {
+	repository Repository
+}
+
+func NewGetCategoryBreadcrumbsHandler(repository Repository) *GetCategoryBreadcrumbsHandler {
+	return &GetCategoryBreadcrumbsHandler{
+		repository: repository,
+	}
+}
+
+type GetCategoryBreadcrumbsRequest struct {
+	ID string `params:"id"`
+}
+
+type GetCategoryBreadcrumbsResponse struct {
+	Breadcrumbs []domain.Category `json:"breadcrumbs"` // From the root down to the category itself
+}
+
+func (h GetCategoryBreadcrumbsHandler) Handle(ctx context.Context, req *GetCategoryBreadcrumbsRequest) (*GetCategoryBreadcrumbsResponse, error) {
+	path, err := h.repository.GetCategoryPath(ctx, req.ID)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.breadcrumbs.failed",
+			"Failed to retrieve category ancestors",
+			nil,
+		)
+	}
+
+	if len(path) == 0 {
+		return nil, httperror.NotFound(
+			"category.breadcrumbs.not_found",
+			"Category not found",
+			nil,
+		)
+	}
+
+	return &GetCategoryBreadcrumbsResponse{
+		Breadcrumbs: path,
+	}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"auction/domain"
 	"auction/pkg/httperror"
 	"context"
+	"database/sql"
+	"errors"
 )
 
 type GetCategoryHandler struct {
@@ -17,7 +19,7 @@ func NewGetCategoryHandler(repository Repository) *GetCategoryHandler {
 }
 
 type GetCategoryRequest struct {
-	ID string `query:"id"`
+	ID string `params:"id"`
 }
 
 type GetCategoryResponse struct {
@@ -27,6 +29,14 @@ type GetCategoryResponse struct {
 func (h GetCategoryHandler) Handle(ctx context.Context, req *GetCategoryRequest) (*GetCategoryResponse, error) {
 	category, err := h.repository.GetCategoryByID(ctx, req.ID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.show.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
 		return nil, httperror.InternalServerError(
 			"category.index.failed",
 			"Failed to retrieve categories",
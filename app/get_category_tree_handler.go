@@ -0,0 +1,39 @@
+package app
+
+import (
+	"auction/pkg/httperror"
+	"context"
+)
+
+type GetCategoryTreeHandler struct {
+	repository Repository
+}
+
+func NewGetCategoryTreeHandler(repository Repository) *GetCategoryTreeHandler {
+	return &GetCategoryTreeHandler{
+		repository: repository,
+	}
+}
+
+type GetCategoryTreeRequest struct {
+	IncludeArchived bool `query:"includeArchived"`
+}
+
+type GetCategoryTreeResponse struct {
+	Categories []*CategoryNode `json:"categories"`
+}
+
+func (h GetCategoryTreeHandler) Handle(ctx context.Context, req *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error) {
+	categories, err := h.repository.GetCategoryTree(ctx, req.IncludeArchived)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.tree.failed",
+			"Failed to retrieve the category tree",
+			nil,
+		)
+	}
+
+	return &GetCategoryTreeResponse{
+		Categories: NewCategoryTree(categories),
+	}, nil
+}
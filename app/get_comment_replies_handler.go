@@ -0,0 +1,116 @@
+package app
+
+import (
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type GetCommentRepliesHandler struct {
+	repository Repository
+}
+
+func NewGetCommentRepliesHandler(repository Repository) *GetCommentRepliesHandler {
+	return &GetCommentRepliesHandler{
+		repository: repository,
+	}
+}
+
+type GetCommentRepliesRequest struct {
+	ItemID       string `params:"itemId" validate:"required,uuid"`
+	CommentID    string `params:"commentId" validate:"required,uuid"`
+	PageSize     int    `query:"limit"`
+	Cursor       string `query:"cursor"`       // nextCursor or prevCursor of a previous response
+	IncludeTotal bool   `query:"includeTotal"` // Count the direct replies of the comment
+}
+
+type GetCommentRepliesResponse struct {
+	Replies []CommentReply `json:"replies"`
+	Pagination
+}
+
+// Handle pages the direct replies to a comment, oldest first. Each reply
+// carries the number of its own replies, which are fetched the same way.
+func (h *GetCommentRepliesHandler) Handle(ctx context.Context, req *GetCommentRepliesRequest) (*GetCommentRepliesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comments.replies.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comments.replies.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	comment, err := h.repository.GetCommentByID(ctx, req.CommentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError(
+			"comments.replies.failed",
+			"Failed to get comment",
+			nil,
+		)
+	}
+	if err != nil || comment.ItemID != req.ItemID {
+		return nil, httperror.NotFound(
+			"comments.replies.not_found",
+			"Comment not found",
+			nil,
+		)
+	}
+
+	var cursor *Cursor
+	if req.Cursor != "" {
+		cursor, err = DecodeCursor(req.Cursor, "", CursorTime)
+		if err != nil {
+			return nil, httperror.BadRequest(
+				"comments.replies.invalid_cursor",
+				"Invalid cursor",
+				nil,
+			)
+		}
+	}
+
+	pageRequest := PageRequest{Cursor: cursor, Limit: clampPageSize(req.PageSize)}
+
+	replies, more, err := h.repository.GetCommentRepliesPage(ctx, comment.ID, pageRequest)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"comments.replies.failed",
+			"Comments repository failed to retrieve replies",
+			nil,
+		)
+	}
+
+	for i, reply := range replies {
+		replies[i].ItemComment = reply.ItemComment.Redacted()
+	}
+
+	var totalItems *int
+	if req.IncludeTotal {
+		count, err := h.repository.CountCommentReplies(ctx, comment.ID)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.replies.count_failed",
+				"Failed to count replies",
+				nil,
+			)
+		}
+		totalItems = &count
+	}
+
+	return &GetCommentRepliesResponse{
+		Replies:    replies,
+		Pagination: cursorPagination(replies, pageRequest, more, totalItems, "", replyCursorKey),
+	}, nil
+}
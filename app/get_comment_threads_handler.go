@@ -0,0 +1,151 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultThreadReplies is the number of replies loaded per thread unless requested otherwise
+const DefaultThreadReplies = 3
+
+type GetCommentThreadsHandler struct {
+	repository Repository
+}
+
+func NewGetCommentThreadsHandler(repository Repository) *GetCommentThreadsHandler {
+	return &GetCommentThreadsHandler{
+		repository: repository,
+	}
+}
+
+type GetCommentThreadsRequest struct {
+	ID           string `params:"id" validate:"required,uuid"`
+	Page         int    `query:"page"`
+	PageSize     int    `query:"limit"`
+	Cursor       string `query:"cursor"`                                  // nextCursor or prevCursor of a previous response; replaces page
+	IncludeTotal bool   `query:"includeTotal"`                            // Count the total with a cursor; page requests always do
+	Replies      *int   `query:"replies" validate:"omitnil,min=0,max=50"` // Replies loaded per thread
+}
+
+type GetCommentThreadsResponse struct {
+	Threads []*CommentThread `json:"threads"`
+	Pagination
+}
+
+// Handle lists the top-level comments of an item, newest first, each with the
+// first replies of its reply tree. Totals and cursors count threads, not replies.
+func (h *GetCommentThreadsHandler) Handle(ctx context.Context, req *GetCommentThreadsRequest) (*GetCommentThreadsResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comments.threads.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comments.threads.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	page := max(req.Page, 1)
+	pageSize := clampPageSize(req.PageSize)
+
+	var roots []domain.ItemComment
+	var pagination Pagination
+
+	if req.Cursor != "" {
+		cursor, err := DecodeCursor(req.Cursor, "", CursorTime)
+		if err != nil {
+			return nil, httperror.BadRequest(
+				"comments.threads.invalid_cursor",
+				"Invalid cursor",
+				nil,
+			)
+		}
+
+		pageRequest := PageRequest{Cursor: cursor, Limit: pageSize}
+
+		var more bool
+		roots, more, err = h.repository.GetRootCommentsPage(ctx, req.ID, pageRequest)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.threads.failed",
+				"Comments repository failed to retrieve comments",
+				nil,
+			)
+		}
+
+		var totalItems *int
+		if req.IncludeTotal {
+			count, err := h.repository.CountRootComments(ctx, req.ID)
+			if err != nil {
+				return nil, httperror.InternalServerError(
+					"comments.threads.count_failed",
+					"Failed to count comments",
+					nil,
+				)
+			}
+			totalItems = &count
+		}
+
+		pagination = cursorPagination(roots, pageRequest, more, totalItems, "", commentCursorKey)
+	} else {
+		var err error
+		roots, err = h.repository.GetRootCommentsByItemID(ctx, req.ID, page, pageSize)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.threads.failed",
+				"Comments repository failed to retrieve comments",
+				nil,
+			)
+		}
+
+		totalItems, err := h.repository.CountRootComments(ctx, req.ID)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.threads.count_failed",
+				"Failed to count comments",
+				nil,
+			)
+		}
+
+		pagination = offsetPagination(roots, page, pageSize, totalItems, "", commentCursorKey)
+	}
+
+	limit := DefaultThreadReplies
+	if req.Replies != nil {
+		limit = *req.Replies
+	}
+
+	rootIDs := make([]string, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+
+	replies := map[string]CommentReplies{}
+	if len(rootIDs) > 0 {
+		var err error
+		replies, err = h.repository.GetCommentReplies(ctx, rootIDs, limit)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.threads.failed",
+				"Comments repository failed to retrieve replies",
+				nil,
+			)
+		}
+	}
+
+	return &GetCommentThreadsResponse{
+		Threads:    newCommentThreads(roots, replies),
+		Pagination: pagination,
+	}, nil
+}
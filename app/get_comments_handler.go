@@ -17,22 +17,25 @@ func NewGetCommentsHandler(repository Repository) *GetCommentsHandler {
 }
 
 type GetCommentsRequest struct {
-	ID       string `params:"id"`
-	Page     int    `query:"page"`
-	PageSize int    `query:"limit"`
+	ID           string `params:"id"`
+	Page         int    `query:"page"`
+	PageSize     int    `query:"limit"`
+	Cursor       string `query:"cursor"`       // nextCursor or prevCursor of a previous response; replaces page
+	IncludeTotal bool   `query:"includeTotal"` // Count the total with a cursor; page requests always do
 }
 
 type GetCommentsResponse struct {
-	Comments   []domain.ItemComment `json:"comments"`
-	Page       int                  `json:"page"`
-	PageSize   int                  `json:"pageSize"`
-	TotalItems int                  `json:"totalItems"`
-	TotalPages int                  `json:"totalPages"`
+	Comments []domain.ItemComment `json:"comments"`
+	Pagination
 }
 
 func (h *GetCommentsHandler) Handle(ctx context.Context, req *GetCommentsRequest) (*GetCommentsResponse, error) {
 	page := max(req.Page, 1)
-	pageSize := max(req.PageSize, 10)
+	pageSize := clampPageSize(req.PageSize)
+
+	if req.Cursor != "" {
+		return h.handleCursor(ctx, req, pageSize)
+	}
 
 	comments, err := h.repository.GetItemCommentsByItemID(ctx, req.ID, page, pageSize)
 	if err != nil {
@@ -52,13 +55,61 @@ func (h *GetCommentsHandler) Handle(ctx context.Context, req *GetCommentsRequest
 		)
 	}
 
-	totalPages := (totalItems + pageSize - 1) / pageSize
+	return &GetCommentsResponse{
+		Comments:   redactComments(comments),
+		Pagination: offsetPagination(comments, page, pageSize, totalItems, "", commentCursorKey),
+	}, nil
+}
+
+func (h *GetCommentsHandler) handleCursor(ctx context.Context, req *GetCommentsRequest, pageSize int) (*GetCommentsResponse, error) {
+	cursor, err := DecodeCursor(req.Cursor, "", CursorTime)
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"comments.index.invalid_cursor",
+			"Invalid cursor",
+			nil,
+		)
+	}
+
+	pageRequest := PageRequest{Cursor: cursor, Limit: pageSize}
+
+	comments, more, err := h.repository.GetItemCommentsPage(ctx, req.ID, pageRequest)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"comments.index.failed",
+			"Comments repository failed to retrieve comments",
+			nil,
+		)
+	}
+
+	var totalItems *int
+	if req.IncludeTotal {
+		count, err := h.repository.CountItemComments(ctx, req.ID)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"comments.count_comments.failed",
+				"Failed to count comments",
+				nil,
+			)
+		}
+		totalItems = &count
+	}
 
 	return &GetCommentsResponse{
-		Comments:   comments,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		Comments:   redactComments(comments),
+		Pagination: cursorPagination(comments, pageRequest, more, totalItems, "", commentCursorKey),
 	}, nil
 }
+
+// redactComments turns hidden, held and deleted comments into tombstones
+func redactComments(comments []domain.ItemComment) []domain.ItemComment {
+	for i, comment := range comments {
+		comments[i] = comment.Redacted()
+	}
+
+	return comments
+}
+
+func commentCursorKey(comment domain.ItemComment) (string, string) {
+	return formatCursorTime(comment.CreatedAt), comment.ID
+}
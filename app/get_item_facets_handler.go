@@ -0,0 +1,101 @@
+package app
+
+import (
+	"auction/pkg/httperror"
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const maxPriceBuckets = 20
+
+type GetItemFacetsHandler struct {
+	repository Repository
+}
+
+func NewGetItemFacetsHandler(repository Repository) *GetItemFacetsHandler {
+	return &GetItemFacetsHandler{
+		repository: repository,
+	}
+}
+
+type GetItemFacetsRequest struct {
+	PriceBuckets        []string `query:"priceBuckets"` // Ascending boundaries, repeatable or comma separated, e.g. priceBuckets=100,500
+	AttributeValueLimit int      `query:"attributeValues"`
+	ItemFilterQuery
+}
+
+type GetItemFacetsResponse struct {
+	Facets ItemFacets `json:"facets"`
+}
+
+// Handle counts the items matching the listing filters per status, category,
+// attribute value and price bucket. All filters apply to every facet.
+func (h GetItemFacetsHandler) Handle(ctx context.Context, req *GetItemFacetsRequest) (*GetItemFacetsResponse, error) {
+	viewerID, _ := ctx.Value("UserID").(string)
+	filter, err := req.filter(viewerID)
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"item.facets.invalid_filter",
+			"Invalid filter",
+			err.Error(),
+		)
+	}
+
+	options, err := req.options()
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"item.facets.invalid_filter",
+			"Invalid filter",
+			err.Error(),
+		)
+	}
+
+	facets, err := h.repository.GetItemFacets(ctx, filter, options)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"item.facets.failed",
+			"Failed to count item facets",
+			nil,
+		)
+	}
+
+	return &GetItemFacetsResponse{
+		Facets: facets,
+	}, nil
+}
+
+func (r GetItemFacetsRequest) options() (ItemFacetOptions, error) {
+	options := ItemFacetOptions{
+		PriceBuckets:        DefaultPriceBuckets,
+		AttributeValueLimit: 20,
+	}
+
+	if r.AttributeValueLimit > 0 {
+		options.AttributeValueLimit = min(r.AttributeValueLimit, maxPageSize)
+	}
+
+	bounds := splitQueryValues(r.PriceBuckets)
+	if len(bounds) == 0 {
+		return options, nil
+	}
+
+	if len(bounds) > maxPriceBuckets {
+		return options, fmt.Errorf("priceBuckets accepts at most %d boundaries", maxPriceBuckets)
+	}
+
+	options.PriceBuckets = make([]decimal.Decimal, len(bounds))
+	for i, bound := range bounds {
+		d, err := decimal.NewFromString(bound)
+		if err != nil {
+			return options, fmt.Errorf("priceBuckets must be decimal numbers")
+		}
+		if i > 0 && !d.GreaterThan(options.PriceBuckets[i-1]) {
+			return options, fmt.Errorf("priceBuckets must be in ascending order")
+		}
+		options.PriceBuckets[i] = d
+	}
+
+	return options, nil
+}
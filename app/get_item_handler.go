@@ -23,7 +23,8 @@ type GetItemRequest struct {
 }
 
 type GetItemResponse struct {
-	Item domain.Item `json:"item"`
+	Item         PublicItem        `json:"item"`
+	PrimaryImage *domain.ItemImage `json:"primaryImage"` // The cover photo, null without images
 }
 
 func (h GetItemHandler) Handle(ctx context.Context, req *GetItemRequest) (*GetItemResponse, error) {
@@ -44,7 +45,21 @@ func (h GetItemHandler) Handle(ctx context.Context, req *GetItemRequest) (*GetIt
 		)
 	}
 
+	var primaryImage *domain.ItemImage
+	image, err := h.repository.GetPrimaryItemImage(ctx, item.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError(
+			"item.show.failed",
+			"Failed to retrieve primary image",
+			nil,
+		)
+	}
+	if err == nil {
+		primaryImage = &image
+	}
+
 	return &GetItemResponse{
-		Item: item,
+		Item:         NewPublicItem(item),
+		PrimaryImage: primaryImage,
 	}, nil
 }
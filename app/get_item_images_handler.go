@@ -5,6 +5,7 @@ import (
 	"auction/pkg/httperror"
 	"context"
 	"database/sql"
+	"strconv"
 )
 
 type GetItemImagesHandler struct {
@@ -18,22 +19,21 @@ func NewGetItemImagesHandler(repository Repository) *GetItemImagesHandler {
 }
 
 type GetItemImagesRequest struct {
-	ItemID   string `params:"id" validate:"required,uuid"`
-	Page     int    `query:"page"`
-	PageSize int    `query:"limit"`
+	ItemID       string `params:"id" validate:"required,uuid"`
+	Page         int    `query:"page"`
+	PageSize     int    `query:"limit"`
+	Cursor       string `query:"cursor"`       // nextCursor or prevCursor of a previous response; replaces page
+	IncludeTotal bool   `query:"includeTotal"` // Count the total with a cursor; page requests always do
 }
 
 type GetItemImagesResponse struct {
-	Images     []domain.ItemImage `json:"images"`
-	Page       int                `json:"page"`
-	PageSize   int                `json:"pageSize"`
-	TotalItems int                `json:"totalItems"`
-	TotalPages int                `json:"totalPages"`
+	Images []ItemImageWithVariants `json:"images"`
+	Pagination
 }
 
 func (h *GetItemImagesHandler) Handle(ctx context.Context, req *GetItemImagesRequest) (*GetItemImagesResponse, error) {
 	page := max(req.Page, 1)
-	pageSize := max(req.PageSize, 10)
+	pageSize := clampPageSize(req.PageSize)
 
 	_, err := h.repository.GetItem(ctx, req.ItemID)
 	if err != nil {
@@ -44,6 +44,10 @@ func (h *GetItemImagesHandler) Handle(ctx context.Context, req *GetItemImagesReq
 		return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", nil)
 	}
 
+	if req.Cursor != "" {
+		return h.handleCursor(ctx, req, pageSize)
+	}
+
 	images, err := h.repository.GetItemImages(ctx, req.ItemID, page, pageSize)
 	if err != nil {
 		return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", []string{
@@ -58,13 +62,72 @@ func (h *GetItemImagesHandler) Handle(ctx context.Context, req *GetItemImagesReq
 		})
 	}
 
-	totalPages := (totalItems + pageSize - 1) / pageSize
+	withVariants, err := h.withVariants(ctx, images)
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", []string{
+			err.Error(),
+		})
+	}
 
 	return &GetItemImagesResponse{
-		Images:     images,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		Images:     withVariants,
+		Pagination: offsetPagination(images, page, pageSize, totalItems, "", imageCursorKey),
 	}, nil
 }
+
+func (h *GetItemImagesHandler) handleCursor(ctx context.Context, req *GetItemImagesRequest, pageSize int) (*GetItemImagesResponse, error) {
+	cursor, err := DecodeCursor(req.Cursor, "", CursorInt)
+	if err != nil {
+		return nil, httperror.BadRequest("item_images.index.invalid_cursor", "Invalid cursor", nil)
+	}
+
+	pageRequest := PageRequest{Cursor: cursor, Limit: pageSize}
+
+	images, more, err := h.repository.GetItemImagesPage(ctx, req.ItemID, pageRequest)
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", []string{
+			err.Error(),
+		})
+	}
+
+	var totalItems *int
+	if req.IncludeTotal {
+		count, err := h.repository.CountItemImages(ctx, req.ItemID)
+		if err != nil {
+			return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", []string{
+				err.Error(),
+			})
+		}
+		totalItems = &count
+	}
+
+	withVariants, err := h.withVariants(ctx, images)
+	if err != nil {
+		return nil, httperror.InternalServerError("item_images.index.internal_error", "Internal server error", []string{
+			err.Error(),
+		})
+	}
+
+	return &GetItemImagesResponse{
+		Images:     withVariants,
+		Pagination: cursorPagination(images, pageRequest, more, totalItems, "", imageCursorKey),
+	}, nil
+}
+
+func (h *GetItemImagesHandler) withVariants(ctx context.Context, images []domain.ItemImage) ([]ItemImageWithVariants, error) {
+	imageIDs := make([]string, 0, len(images))
+	for _, image := range images {
+		imageIDs = append(imageIDs, image.ID)
+	}
+
+	variants, err := h.repository.GetItemImageVariants(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewItemImagesWithVariants(images, variants), nil
+}
+
+func imageCursorKey(image domain.ItemImage) (string, string) {
+	return strconv.Itoa(image.DisplayOrder), image.ID
+}
@@ -4,6 +4,14 @@ import (
 	"auction/domain"
 	"auction/pkg/httperror"
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type GetItemsHandler struct {
@@ -17,25 +25,53 @@ func NewGetItemsHandler(repository Repository) *GetItemsHandler {
 }
 
 type GetItemsRequest struct {
-	Page     int `query:"page"`
-	PageSize int `query:"pageSize"`
+	Page         int    `query:"page"`
+	PageSize     int    `query:"pageSize"`
+	Cursor       string `query:"cursor"`       // nextCursor or prevCursor of a previous response; replaces page
+	IncludeTotal bool   `query:"includeTotal"` // Count the total with a cursor; page requests always do
+	ItemFilterQuery
+}
+
+// ItemFilterQuery holds the filter query parameters shared by the item listing and its facets
+type ItemFilterQuery struct {
+	Status       []string `query:"status"`     // Repeatable or comma separated, e.g. status=active,scheduled
+	CategoryID   string   `query:"categoryId"` // Includes items of descendant categories
+	SellerID     string   `query:"sellerId"`   // Sellers may also list their own draft and cancelled items
+	MinPrice     string   `query:"minPrice"`
+	MaxPrice     string   `query:"maxPrice"`
+	Currency     string   `query:"currency"`
+	EndingBefore string   `query:"endingBefore"` // RFC 3339
+	EndingAfter  string   `query:"endingAfter"`  // RFC 3339
+	Attribute    []string `query:"attribute"`    // Repeatable key:value pairs, e.g. attribute=brand:Leica
+	Sort         string   `query:"sort"`         // newest (default), ending_soon, price_asc, price_desc, most_bids
 }
 
 type GetItemsResponse struct {
-	Items      []domain.Item `json:"items"`
-	Page       int           `json:"page"`
-	PageSize   int           `json:"pageSize"`
-	TotalItems int           `json:"totalItems"`
-	TotalPages int           `json:"totalPages"`
+	Items []PublicItem `json:"items"`
+	Pagination
 }
 
 func (h GetItemsHandler) Handle(ctx context.Context, req *GetItemsRequest) (*GetItemsResponse, error) {
 	page := max(req.Page, 1)
-	pageSize := max(req.PageSize, 10)
+	pageSize := clampPageSize(req.PageSize)
 
 	offset := (page - 1) * pageSize
 
-	items, err := h.repository.GetItems(ctx, pageSize, offset)
+	viewerID, _ := ctx.Value("UserID").(string)
+	filter, err := req.filter(viewerID)
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"item.index.invalid_filter",
+			"Invalid filter",
+			err.Error(),
+		)
+	}
+
+	if req.Cursor != "" {
+		return h.handleCursor(ctx, req, filter, pageSize)
+	}
+
+	items, err := h.repository.GetItems(ctx, filter, pageSize, offset)
 	if err != nil {
 		return nil, httperror.InternalServerError(
 			"item.index.failed",
@@ -44,7 +80,7 @@ func (h GetItemsHandler) Handle(ctx context.Context, req *GetItemsRequest) (*Get
 		)
 	}
 
-	totalItems, err := h.repository.CountItems(ctx)
+	totalItems, err := h.repository.CountItems(ctx, filter)
 	if err != nil {
 		return nil, httperror.InternalServerError(
 			"item.count_items.failed",
@@ -53,13 +89,189 @@ func (h GetItemsHandler) Handle(ctx context.Context, req *GetItemsRequest) (*Get
 		)
 	}
 
-	totalPages := (totalItems + pageSize - 1) / pageSize
+	return &GetItemsResponse{
+		Items:      NewPublicItems(items),
+		Pagination: offsetPagination(items, page, pageSize, totalItems, string(filter.Sort), itemCursorKey(filter.Sort)),
+	}, nil
+}
+
+func (h GetItemsHandler) handleCursor(ctx context.Context, req *GetItemsRequest, filter ItemFilter, pageSize int) (*GetItemsResponse, error) {
+	cursor, err := DecodeCursor(req.Cursor, string(filter.Sort), itemCursorValue(filter.Sort))
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"item.index.invalid_cursor",
+			"Invalid cursor",
+			nil,
+		)
+	}
+
+	pageRequest := PageRequest{Cursor: cursor, Limit: pageSize}
+
+	items, more, err := h.repository.GetItemsPage(ctx, filter, pageRequest)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"item.index.failed",
+			"Failed to retrieve items",
+			nil,
+		)
+	}
+
+	var totalItems *int
+	if req.IncludeTotal {
+		count, err := h.repository.CountItems(ctx, filter)
+		if err != nil {
+			return nil, httperror.InternalServerError(
+				"item.count_items.failed",
+				"Failed to count items",
+				nil,
+			)
+		}
+		totalItems = &count
+	}
 
 	return &GetItemsResponse{
-		Items:      items,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		Items:      NewPublicItems(items),
+		Pagination: cursorPagination(items, pageRequest, more, totalItems, string(filter.Sort), itemCursorKey(filter.Sort)),
 	}, nil
 }
+
+// itemCursorValue returns the type of the sort key of items in the given sort order
+func itemCursorValue(itemSort ItemSort) CursorValue {
+	switch itemSort {
+	case ItemSortPriceAsc, ItemSortPriceDesc:
+		return CursorDecimal
+	case ItemSortMostBids:
+		return CursorInt
+	default:
+		return CursorTime
+	}
+}
+
+// itemCursorKey returns the cursor key of items in the given sort order
+func itemCursorKey(itemSort ItemSort) cursorKey[domain.Item] {
+	return func(item domain.Item) (string, string) {
+		switch itemSort {
+		case ItemSortEndingSoon:
+			return formatCursorTime(item.EndDate), item.ID
+		case ItemSortPriceAsc, ItemSortPriceDesc:
+			return item.CurrentPrice.String(), item.ID
+		case ItemSortMostBids:
+			return strconv.Itoa(item.BidCount), item.ID
+		default:
+			return formatCursorTime(item.CreatedAt), item.ID
+		}
+	}
+}
+
+// filter converts the query parameters into an ItemFilter for the user
+// viewerID, empty for anonymous requests. Statuses outside the public
+// listings are only accepted when sellers list their own items.
+func (r ItemFilterQuery) filter(viewerID string) (ItemFilter, error) {
+	filter := ItemFilter{Sort: ItemSortNewest}
+
+	ownItems := viewerID != "" && r.SellerID == viewerID
+
+	for _, status := range splitQueryValues(r.Status) {
+		itemStatus := domain.ItemStatus(status)
+		if !itemStatus.IsValid() {
+			return filter, fmt.Errorf("unknown status %q", status)
+		}
+		if !ownItems && !slices.Contains(domain.ListedItemStatuses, itemStatus) {
+			return filter, fmt.Errorf("status %q is only listed for the seller's own items", status)
+		}
+		filter.Statuses = append(filter.Statuses, itemStatus)
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = slices.Clone(domain.ListedItemStatuses)
+	}
+
+	if r.CategoryID != "" {
+		if _, err := uuid.Parse(r.CategoryID); err != nil {
+			return filter, fmt.Errorf("categoryId must be a UUID")
+		}
+		filter.CategoryID = &r.CategoryID
+	}
+	if r.SellerID != "" {
+		if _, err := uuid.Parse(r.SellerID); err != nil {
+			return filter, fmt.Errorf("sellerId must be a UUID")
+		}
+		filter.SellerID = &r.SellerID
+	}
+	if r.Currency != "" {
+		filter.CurrencyCode = &r.Currency
+	}
+
+	var err error
+	if filter.MinPrice, err = parseDecimalQuery("minPrice", r.MinPrice); err != nil {
+		return filter, err
+	}
+	if filter.MaxPrice, err = parseDecimalQuery("maxPrice", r.MaxPrice); err != nil {
+		return filter, err
+	}
+	if filter.EndingBefore, err = parseTimeQuery("endingBefore", r.EndingBefore); err != nil {
+		return filter, err
+	}
+	if filter.EndingAfter, err = parseTimeQuery("endingAfter", r.EndingAfter); err != nil {
+		return filter, err
+	}
+
+	for _, attribute := range r.Attribute {
+		key, value, ok := strings.Cut(attribute, ":")
+		if !ok || key == "" {
+			return filter, fmt.Errorf("attribute must be a key:value pair, got %q", attribute)
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[key] = value
+	}
+
+	if r.Sort != "" {
+		filter.Sort = ItemSort(r.Sort)
+		if !filter.Sort.IsValid() {
+			return filter, fmt.Errorf("unknown sort %q", r.Sort)
+		}
+	}
+
+	return filter, nil
+}
+
+// splitQueryValues flattens repeated and comma separated query values
+func splitQueryValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
+
+func parseDecimalQuery(name, value string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a decimal number", name)
+	}
+
+	return &d, nil
+}
+
+func parseTimeQuery(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"auction/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testSellerID = "5f0c7a2e-3b1d-4c8e-9a6f-7d2e1b0c4a93"
+
+func TestItemFilterQuery(t *testing.T) {
+	query := ItemFilterQuery{
+		Status:       []string{"active, scheduled", "ended"},
+		CategoryID:   "0b7e4f5c-8d3a-4a7e-9c1f-2e6d5b4a3c21",
+		SellerID:     testSellerID,
+		MinPrice:     "10.5",
+		MaxPrice:     "100",
+		Currency:     "EUR",
+		EndingBefore: "2026-03-02T00:00:00Z",
+		EndingAfter:  "2026-03-01T00:00:00+01:00",
+		Attribute:    []string{"brand:Leica", "mount:M:39"},
+		Sort:         "price_desc",
+	}
+
+	filter, err := query.filter("")
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+
+	if want := []domain.ItemStatus{domain.ItemStatusActive, domain.ItemStatusScheduled, domain.ItemStatusEnded}; !slices.Equal(filter.Statuses, want) {
+		t.Errorf("statuses = %v, want %v", filter.Statuses, want)
+	}
+	if *filter.CategoryID != query.CategoryID || *filter.SellerID != testSellerID || *filter.CurrencyCode != "EUR" {
+		t.Errorf("category, seller, currency = %s, %s, %s", *filter.CategoryID, *filter.SellerID, *filter.CurrencyCode)
+	}
+	if filter.MinPrice.String() != "10.5" || filter.MaxPrice.String() != "100" {
+		t.Errorf("prices = %s..%s, want 10.5..100", filter.MinPrice, filter.MaxPrice)
+	}
+	if !filter.EndingBefore.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) || !filter.EndingAfter.Equal(time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("ending = %s..%s", filter.EndingAfter, filter.EndingBefore)
+	}
+	if len(filter.Attributes) != 2 || filter.Attributes["brand"] != "Leica" || filter.Attributes["mount"] != "M:39" {
+		t.Errorf("attributes = %v", filter.Attributes)
+	}
+	if filter.Sort != ItemSortPriceDesc {
+		t.Errorf("sort = %s, want %s", filter.Sort, ItemSortPriceDesc)
+	}
+}
+
+func TestItemFilterQueryDefaults(t *testing.T) {
+	filter, err := ItemFilterQuery{}.filter("")
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+
+	if !slices.Equal(filter.Statuses, domain.ListedItemStatuses) || filter.Sort != ItemSortNewest {
+		t.Errorf("statuses = %v, sort = %s; want the listed statuses, newest", filter.Statuses, filter.Sort)
+	}
+	if filter.CategoryID != nil || filter.SellerID != nil || filter.MinPrice != nil || filter.EndingBefore != nil || filter.Attributes != nil {
+		t.Errorf("filter = %+v, want no other filters", filter)
+	}
+}
+
+func TestItemFilterQueryRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ItemFilterQuery
+	}{
+		{"unknown status", ItemFilterQuery{Status: []string{"active,gone"}}},
+		{"category not a uuid", ItemFilterQuery{CategoryID: "cameras"}},
+		{"seller not a uuid", ItemFilterQuery{SellerID: "abc"}},
+		{"bad min price", ItemFilterQuery{MinPrice: "ten"}},
+		{"bad max price", ItemFilterQuery{MaxPrice: "1,5"}},
+		{"bad ending before", ItemFilterQuery{EndingBefore: "2026-03-02"}},
+		{"bad ending after", ItemFilterQuery{EndingAfter: "tomorrow"}},
+		{"attribute without value", ItemFilterQuery{Attribute: []string{"brand"}}},
+		{"attribute without key", ItemFilterQuery{Attribute: []string{":Leica"}}},
+		{"unknown sort", ItemFilterQuery{Sort: "cheapest"}},
+	}
+
+	for _, tt := range tests {
+		if _, err := tt.query.filter(""); err == nil {
+			t.Errorf("%s: err = nil, want an error", tt.name)
+		}
+	}
+}
+
+func TestItemFilterQueryUnlistedStatuses(t *testing.T) {
+	otherSellerID := "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+
+	tests := []struct {
+		name     string
+		query    ItemFilterQuery
+		viewerID string
+		wantErr  bool
+	}{
+		{"anonymous", ItemFilterQuery{Status: []string{"draft"}}, "", true},
+		{"anonymous for a seller", ItemFilterQuery{Status: []string{"draft"}, SellerID: testSellerID}, "", true},
+		{"signed in without a seller", ItemFilterQuery{Status: []string{"cancelled"}}, testSellerID, true},
+		{"another seller", ItemFilterQuery{Status: []string{"active,draft"}, SellerID: otherSellerID}, testSellerID, true},
+		{"own items", ItemFilterQuery{Status: []string{"draft,cancelled"}, SellerID: testSellerID}, testSellerID, false},
+		{"listed statuses of another seller", ItemFilterQuery{Status: []string{"active"}, SellerID: otherSellerID}, testSellerID, false},
+	}
+
+	for _, tt := range tests {
+		filter, err := tt.query.filter(tt.viewerID)
+
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: statuses = %v, want an error", tt.name, filter.Statuses)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: err = %v, want nil", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type GetModerationCommentsHandler struct {
+	repository Repository
+}
+
+func NewGetModerationCommentsHandler(repository Repository) *GetModerationCommentsHandler {
+	return &GetModerationCommentsHandler{
+		repository: repository,
+	}
+}
+
+type GetModerationCommentsRequest struct {
+	Status   string `query:"status" validate:"omitempty,oneof=pending hidden"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"limit"`
+}
+
+type GetModerationCommentsResponse struct {
+	Comments []ReviewedComment `json:"comments"`
+	Pagination
+}
+
+// Handle lists the comments held for review, or the hidden ones, oldest first
+// and unredacted
+func (h *GetModerationCommentsHandler) Handle(ctx context.Context, req *GetModerationCommentsRequest) (*GetModerationCommentsResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comments.moderation.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comments.moderation.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	status := domain.CommentStatusPending
+	if req.Status != "" {
+		status = domain.CommentStatus(req.Status)
+	}
+
+	page := max(req.Page, 1)
+	pageSize := clampPageSize(req.PageSize)
+
+	comments, err := h.repository.GetCommentsByStatus(ctx, status, page, pageSize)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"comments.moderation.failed",
+			"Comments repository failed to retrieve comments",
+			nil,
+		)
+	}
+
+	totalItems, err := h.repository.CountCommentsByStatus(ctx, status)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"comments.moderation.count_failed",
+			"Failed to count comments",
+			nil,
+		)
+	}
+
+	// The queue is paged by number only, so there are no cursors
+	totalPages := (totalItems + pageSize - 1) / pageSize
+
+	return &GetModerationCommentsResponse{
+		Comments: comments,
+		Pagination: Pagination{
+			Page:       page,
+			PageSize:   pageSize,
+			TotalItems: &totalItems,
+			TotalPages: &totalPages,
+		},
+	}, nil
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type GetOwnerItemHandler struct {
+	repository Repository
+}
+
+func NewGetOwnerItemHandler(repository Repository) *GetOwnerItemHandler {
+	return &GetOwnerItemHandler{
+		repository: repository,
+	}
+}
+
+type GetOwnerItemRequest struct {
+	ItemID string `params:"id"`
+}
+
+type GetOwnerItemResponse struct {
+	Item         OwnerItem         `json:"item"`
+	PrimaryImage *domain.ItemImage `json:"primaryImage"` // The cover photo, null without images
+}
+
+// Handle returns the full item, reserve price included, to its seller only
+func (h GetOwnerItemHandler) Handle(ctx context.Context, req *GetOwnerItemRequest) (*GetOwnerItemResponse, error) {
+	userID := ctx.Value("UserID").(string)
+
+	item, err := h.repository.GetUserItem(ctx, req.ItemID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"item.owner_show.not_found",
+				"Item not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.owner_show.failed",
+			"Failed to retrieve item",
+			nil,
+		)
+	}
+
+	var primaryImage *domain.ItemImage
+	image, err := h.repository.GetPrimaryItemImage(ctx, item.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError(
+			"item.owner_show.failed",
+			"Failed to retrieve primary image",
+			nil,
+		)
+	}
+	if err == nil {
+		primaryImage = &image
+	}
+
+	return &GetOwnerItemResponse{
+		Item:         NewOwnerItem(item),
+		PrimaryImage: primaryImage,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type GetSellerQuestionsHandler struct {
+	repository Repository
+}
+
+func NewGetSellerQuestionsHandler(repository Repository) *GetSellerQuestionsHandler {
+	return &GetSellerQuestionsHandler{
+		repository: repository,
+	}
+}
+
+type GetSellerQuestionsRequest struct {
+	ItemID     string `query:"itemId" validate:"omitempty,uuid"`
+	Unanswered bool   `query:"unanswered"`
+	Page       int    `query:"page"`
+	PageSize   int    `query:"limit"`
+}
+
+type GetSellerQuestionsResponse struct {
+	Questions []SellerQuestion `json:"questions"`
+	Pagination
+}
+
+// Handle lists the questions asked on the current user's items, oldest first
+func (h *GetSellerQuestionsHandler) Handle(ctx context.Context, req *GetSellerQuestionsRequest) (*GetSellerQuestionsResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"questions.index.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"questions.index.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	filter := SellerQuestionFilter{
+		SellerID:   ctx.Value("UserID").(string),
+		ItemID:     req.ItemID,
+		Unanswered: req.Unanswered,
+	}
+
+	page := max(req.Page, 1)
+	pageSize := clampPageSize(req.PageSize)
+
+	questions, err := h.repository.GetSellerQuestions(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"questions.index.failed",
+			"Comments repository failed to retrieve questions",
+			nil,
+		)
+	}
+
+	totalItems, err := h.repository.CountSellerQuestions(ctx, filter)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"questions.index.count_failed",
+			"Failed to count questions",
+			nil,
+		)
+	}
+
+	// Paged by number only, so there are no cursors
+	totalPages := (totalItems + pageSize - 1) / pageSize
+
+	return &GetSellerQuestionsResponse{
+		Questions: questions,
+		Pagination: Pagination{
+			Page:       page,
+			PageSize:   pageSize,
+			TotalItems: &totalItems,
+			TotalPages: &totalPages,
+		},
+	}, nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type HideCommentHandler struct {
+	moderation commentModeration
+}
+
+func NewHideCommentHandler(repository Repository, eventPublisher events.Publisher) *HideCommentHandler {
+	return &HideCommentHandler{
+		moderation: commentModeration{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type HideCommentRequest struct {
+	ItemID    string  `params:"itemId" validate:"required,uuid"`
+	CommentID string  `params:"commentId" validate:"required,uuid"`
+	Reason    *string `json:"reason" validate:"omitnil,max=500"`
+}
+
+type HideCommentResponse struct {
+	Comment domain.ItemComment `json:"comment"`
+}
+
+// Handle hides a comment, leaving a tombstone in its thread
+func (h *HideCommentHandler) Handle(ctx context.Context, req *HideCommentRequest) (*HideCommentResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comment.hide.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comment.hide.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	comment, err := h.moderation.moderate(ctx, "comment.hide", req.ItemID, req.CommentID, domain.CommentStatusHidden, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HideCommentResponse{
+		Comment: comment,
+	}, nil
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var errItemAttributeNotFound = errors.New("item attribute not found")
+
+// attributeViolationsError carries the schema violations of a change out of
+// its transaction
+type attributeViolationsError struct {
+	violations []domain.AttributeViolation
+}
+
+func (e *attributeViolationsError) Error() string {
+	return fmt.Sprintf("attributes do not match the schema: %d violations", len(e.violations))
+}
+
+// itemAttributeChange computes the changes to an item's attributes from the
+// ones it has. Violations are returned as an *attributeViolationsError.
+type itemAttributeChange func(schema domain.AttributeSchema, existing []domain.ItemAttribute) (domain.ItemAttributeChanges, error)
+
+// itemAttributesEditor applies attribute changes to the items of the current
+// user. The update and replace handlers only differ in the change.
+type itemAttributesEditor struct {
+	repository     Repository
+	eventPublisher events.Publisher
+}
+
+// edit applies change to an item of the user and returns its attributes
+// afterwards along with the applied changes. Codes of errors are prefixed
+// with action, e.g. "item.attributes.replace".
+func (e itemAttributesEditor) edit(ctx context.Context, action string, itemID string, change itemAttributeChange) ([]domain.ItemAttribute, domain.ItemAttributeChanges, error) {
+	userID := ctx.Value("UserID").(string)
+
+	item, err := e.repository.GetItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ItemAttributeChanges{}, httperror.NotFound(
+				action+".not_found",
+				"Item not found",
+				nil,
+			)
+		}
+
+		return nil, domain.ItemAttributeChanges{}, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get item",
+			nil,
+		)
+	}
+
+	if item.SellerID != userID {
+		return nil, domain.ItemAttributeChanges{}, httperror.Forbidden(
+			action+".forbidden",
+			"You are not authorized to change the attributes of this item",
+			nil,
+		)
+	}
+
+	categoryIDs := make([]string, len(item.Categories))
+	for i, category := range item.Categories {
+		categoryIDs[i] = category.ID
+	}
+
+	schema, err := loadAttributeSchema(ctx, e.repository, categoryIDs)
+	if err != nil {
+		return nil, domain.ItemAttributeChanges{}, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get category attribute schemas",
+			nil,
+		)
+	}
+
+	var attributes []domain.ItemAttribute
+	var applied domain.ItemAttributeChanges
+	err = e.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := e.repository.LockItemAttributes(ctx, item.ID); err != nil {
+			return err
+		}
+
+		existing, err := e.repository.GetItemAttributes(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		changes, err := change(schema, existing)
+		if err != nil {
+			return err
+		}
+
+		applied, err = e.apply(ctx, item.ID, changes)
+		if err != nil {
+			return err
+		}
+
+		attributes, err = e.repository.GetItemAttributes(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		if applied.IsEmpty() {
+			return nil
+		}
+
+		return e.publishEvent(ctx, item, existing, applied)
+	})
+	if err != nil {
+		var violationsErr *attributeViolationsError
+		switch {
+		case errors.As(err, &violationsErr):
+			return nil, domain.ItemAttributeChanges{}, invalidAttributes(action+".invalid_attributes", violationsErr.violations)
+		case errors.Is(err, errItemAttributeNotFound):
+			return nil, domain.ItemAttributeChanges{}, httperror.NotFound(
+				action+".attribute_not_found",
+				"Attribute not found",
+				nil,
+			)
+		}
+
+		return nil, domain.ItemAttributeChanges{}, httperror.InternalServerError(
+			action+".failed",
+			"Failed to change item attributes",
+			nil,
+		)
+	}
+
+	return attributes, applied, nil
+}
+
+// apply stores the changes and returns them as stored. Removals go first so
+// the keys they free can be reused by the other changes.
+func (e itemAttributesEditor) apply(ctx context.Context, itemID string, changes domain.ItemAttributeChanges) (domain.ItemAttributeChanges, error) {
+	applied := domain.ItemAttributeChanges{
+		Removed: changes.Removed,
+		Updated: make([]domain.ItemAttribute, 0, len(changes.Updated)),
+	}
+
+	removedIDs := make([]string, len(changes.Removed))
+	for i, attribute := range changes.Removed {
+		removedIDs[i] = attribute.ID
+	}
+
+	if err := e.repository.DeleteItemAttributes(ctx, itemID, removedIDs); err != nil {
+		return domain.ItemAttributeChanges{}, err
+	}
+
+	for _, attribute := range changes.Updated {
+		updated, err := e.repository.UpdateItemAttribute(ctx, attribute)
+		if err != nil {
+			return domain.ItemAttributeChanges{}, err
+		}
+
+		applied.Updated = append(applied.Updated, updated)
+	}
+
+	added, err := e.repository.CreateItemAttributes(ctx, changes.Added)
+	if err != nil {
+		return domain.ItemAttributeChanges{}, err
+	}
+	applied.Added = added
+
+	return applied, nil
+}
+
+// publishEvent publishes a single item.attributes.changed event for all changes
+func (e itemAttributesEditor) publishEvent(ctx context.Context, item domain.Item, existing []domain.ItemAttribute, changes domain.ItemAttributeChanges) error {
+	if e.eventPublisher == nil {
+		return nil
+	}
+
+	previous := make(map[string]domain.ItemAttribute, len(existing))
+	for _, attribute := range existing {
+		previous[attribute.ID] = attribute
+	}
+
+	eventPayload := events.ItemAttributesChangedPayload{
+		ItemID:    item.ID,
+		SellerID:  item.SellerID,
+		Added:     make([]events.ItemAttributeValue, len(changes.Added)),
+		Updated:   make([]events.ItemAttributeValueChange, len(changes.Updated)),
+		Removed:   make([]events.ItemAttributeValue, len(changes.Removed)),
+		ChangedAt: time.Now().UTC(),
+	}
+
+	for i, attribute := range changes.Added {
+		eventPayload.Added[i] = events.ItemAttributeValue{ID: attribute.ID, Key: attribute.Key, Value: attribute.Value}
+	}
+	for i, attribute := range changes.Updated {
+		eventPayload.Updated[i] = events.ItemAttributeValueChange{
+			ID:            attribute.ID,
+			Key:           attribute.Key,
+			Value:         attribute.Value,
+			PreviousKey:   previous[attribute.ID].Key,
+			PreviousValue: previous[attribute.ID].Value,
+		}
+	}
+	for i, attribute := range changes.Removed {
+		eventPayload.Removed[i] = events.ItemAttributeValue{ID: attribute.ID, Key: attribute.Key, Value: attribute.Value}
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemAttributesChangedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.attributes.changed event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CategoryAssignmentError lists the requested categories that cannot be
+// assigned to an item
+type CategoryAssignmentError struct {
+	Missing  []string `json:"missing,omitempty"`
+	Inactive []string `json:"inactive,omitempty"`
+}
+
+func (e *CategoryAssignmentError) Error() string {
+	return fmt.Sprintf("categories cannot be assigned: missing %v, inactive %v", e.Missing, e.Inactive)
+}
+
+// checkAssignableCategories returns a *CategoryAssignmentError unless every
+// category exists and is active
+func checkAssignableCategories(ctx context.Context, repository Repository, categoryIDs []string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	categories, err := repository.GetCategoriesByIDs(ctx, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	assignmentErr := &CategoryAssignmentError{}
+	for _, id := range categoryIDs {
+		i := slices.IndexFunc(categories, func(category domain.Category) bool {
+			return category.ID == id
+		})
+
+		switch {
+		case i < 0:
+			assignmentErr.Missing = append(assignmentErr.Missing, id)
+		case categories[i].Status != domain.CategoryStatusActive:
+			assignmentErr.Inactive = append(assignmentErr.Inactive, id)
+		}
+	}
+
+	if len(assignmentErr.Missing) > 0 || len(assignmentErr.Inactive) > 0 {
+		return assignmentErr
+	}
+
+	return nil
+}
+
+// categoryAssignmentFailed converts the error of checkAssignableCategories
+func categoryAssignmentFailed(code string, err error) error {
+	var assignmentErr *CategoryAssignmentError
+	if errors.As(err, &assignmentErr) {
+		return httperror.UnprocessableEntity(
+			code,
+			"Categories must exist and be active",
+			assignmentErr,
+		)
+	}
+
+	return nil
+}
+
+// itemCategoryChange is a change to the categories of an item, returning the
+// ids that were added and removed
+type itemCategoryChange func(ctx context.Context, itemID string) (added []string, removed []string, err error)
+
+// itemCategoriesEditor applies category changes to the items of the current
+// user. The add, remove and replace handlers only differ in the change.
+type itemCategoriesEditor struct {
+	repository     Repository
+	eventPublisher events.Publisher
+}
+
+// edit applies change to an item of the user and returns its categories
+// afterwards. Categories the change assigns must exist and be active; codes
+// of errors are prefixed with action, e.g. "item.categories.add".
+func (e itemCategoriesEditor) edit(ctx context.Context, action string, itemID string, assigned []string, change itemCategoryChange) ([]domain.Category, error) {
+	userID := ctx.Value("UserID").(string)
+
+	item, err := e.repository.GetUserItem(ctx, itemID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				action+".not_found",
+				"Item not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get item",
+			nil,
+		)
+	}
+
+	if item.IsFinished() {
+		return nil, httperror.Conflict(
+			action+".finished",
+			"Categories of finished items cannot be changed",
+			map[string]any{"status": item.Status},
+		)
+	}
+
+	// Categories the item already has stay valid even if they were archived since
+	newIDs := slices.DeleteFunc(slices.Clone(assigned), func(id string) bool {
+		return slices.ContainsFunc(item.Categories, func(category domain.Category) bool {
+			return category.ID == id
+		})
+	})
+
+	if err := checkAssignableCategories(ctx, e.repository, newIDs); err != nil {
+		if httpErr := categoryAssignmentFailed(action+".invalid_categories", err); httpErr != nil {
+			return nil, httpErr
+		}
+
+		return nil, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get categories",
+			nil,
+		)
+	}
+
+	var categories []domain.Category
+	err = e.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		added, removed, err := change(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		categories, err = e.repository.GetCategoriesByItemID(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+
+		return e.publishEvent(ctx, item, categories, added, removed)
+	})
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			action+".failed",
+			"Failed to change item categories",
+			nil,
+		)
+	}
+
+	return categories, nil
+}
+
+func (e itemCategoriesEditor) publishEvent(ctx context.Context, item domain.Item, categories []domain.Category, added, removed []string) error {
+	if e.eventPublisher == nil {
+		return nil
+	}
+
+	categoryIDs := make([]string, len(categories))
+	for i, category := range categories {
+		categoryIDs[i] = category.ID
+	}
+
+	eventPayload := events.ItemCategoriesChangedPayload{
+		ItemID:      item.ID,
+		SellerID:    item.SellerID,
+		CategoryIDs: categoryIDs,
+		Added:       append([]string{}, added...),
+		Removed:     append([]string{}, removed...),
+		ChangedAt:   time.Now().UTC(),
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemCategoriesChangedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.categories.changed event: %w", err)
+	}
+
+	return nil
+}
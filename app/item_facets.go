@@ -0,0 +1,63 @@
+package app
+
+import (
+	"auction/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultPriceBuckets are the price bucket boundaries used when a request does not set its own
+var DefaultPriceBuckets = []decimal.Decimal{
+	decimal.NewFromInt(10),
+	decimal.NewFromInt(50),
+	decimal.NewFromInt(100),
+	decimal.NewFromInt(500),
+	decimal.NewFromInt(1000),
+	decimal.NewFromInt(5000),
+}
+
+// ItemFacets counts the items matching a filter, broken down per facet
+type ItemFacets struct {
+	Total        int                `json:"total"`
+	Statuses     []StatusFacet      `json:"statuses"`
+	Categories   []CategoryFacet    `json:"categories"`
+	Attributes   []AttributeFacet   `json:"attributes"`
+	PriceBuckets []PriceBucketFacet `json:"priceBuckets"`
+}
+
+type StatusFacet struct {
+	Status domain.ItemStatus `json:"status"`
+	Count  int               `json:"count"`
+}
+
+// CategoryFacet counts the items directly assigned to a category
+type CategoryFacet struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type AttributeFacet struct {
+	Key    string                `json:"key"`
+	Values []AttributeValueFacet `json:"values"` // Most frequent first
+}
+
+type AttributeValueFacet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PriceBucketFacet counts the items of one currency whose current price is
+// in [Min, Max). The first bucket has no Min and the last one no Max.
+type PriceBucketFacet struct {
+	CurrencyCode string           `json:"currencyCode"`
+	Min          *decimal.Decimal `json:"min"`
+	Max          *decimal.Decimal `json:"max"`
+	Count        int              `json:"count"`
+}
+
+// ItemFacetOptions tunes the facet computation
+type ItemFacetOptions struct {
+	PriceBuckets        []decimal.Decimal // Ascending bucket boundaries
+	AttributeValueLimit int               // Values counted per attribute key
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"auction/domain"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ItemSort string
+
+// Item listing sort orders
+const (
+	ItemSortNewest     ItemSort = "newest"
+	ItemSortEndingSoon ItemSort = "ending_soon"
+	ItemSortPriceAsc   ItemSort = "price_asc"
+	ItemSortPriceDesc  ItemSort = "price_desc"
+	ItemSortMostBids   ItemSort = "most_bids"
+)
+
+// ItemFilter narrows down an item listing. Zero values mean "no filter";
+// all set fields must match.
+type ItemFilter struct {
+	Statuses     []domain.ItemStatus
+	CategoryID   *string // Matches the category and all of its descendants
+	SellerID     *string
+	MinPrice     *decimal.Decimal // Compared with the current price
+	MaxPrice     *decimal.Decimal
+	CurrencyCode *string
+	EndingBefore *time.Time
+	EndingAfter  *time.Time
+	Attributes   map[string]string // Attribute key/value pairs the item must all have
+	Sort         ItemSort
+}
+
+// IsValid reports whether s is a supported sort order
+func (s ItemSort) IsValid() bool {
+	switch s {
+	case ItemSortNewest, ItemSortEndingSoon, ItemSortPriceAsc, ItemSortPriceDesc, ItemSortMostBids:
+		return true
+	default:
+		return false
+	}
+}
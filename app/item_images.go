@@ -0,0 +1,275 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var errItemImageNotFound = errors.New("item image not found")
+
+// imageOrderMismatchError carries the ids that keep a new order from listing
+// every image of the item exactly once out of its transaction
+type imageOrderMismatchError struct {
+	missing []string // Images of the item left out of the order
+	unknown []string // Ids in the order that are not images of the item
+}
+
+func (e *imageOrderMismatchError) Error() string {
+	return fmt.Sprintf("image order does not match the item's images: %d missing, %d unknown", len(e.missing), len(e.unknown))
+}
+
+// itemImageChange rearranges the images of an item, given in display order
+type itemImageChange func(ctx context.Context, images []domain.ItemImage) error
+
+// itemImagesEditor rearranges the images of the items of the current user.
+// The order and primary image handlers only differ in the change.
+type itemImagesEditor struct {
+	repository     Repository
+	eventPublisher events.Publisher
+}
+
+// arrange applies change to an item of the user and returns its images in
+// display order afterwards. Codes of errors are prefixed with action, e.g.
+// "item_images.order".
+func (e itemImagesEditor) arrange(ctx context.Context, action string, itemID string, change itemImageChange) ([]domain.ItemImage, error) {
+	userID := ctx.Value("UserID").(string)
+
+	item, err := e.repository.GetItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				action+".not_found",
+				"Item not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			action+".failed",
+			"Failed to get item",
+			nil,
+		)
+	}
+
+	if item.SellerID != userID {
+		return nil, httperror.Forbidden(
+			action+".forbidden",
+			"You are not authorized to change the images of this item",
+			nil,
+		)
+	}
+
+	var images []domain.ItemImage
+	err = e.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := e.repository.LockItemImages(ctx, item.ID); err != nil {
+			return err
+		}
+
+		existing, err := e.repository.GetAllItemImages(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := change(ctx, existing); err != nil {
+			return err
+		}
+
+		images, err = e.repository.GetAllItemImages(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		return e.publishEvents(ctx, item, existing, images)
+	})
+	if err != nil {
+		var mismatchErr *imageOrderMismatchError
+		switch {
+		case errors.As(err, &mismatchErr):
+			return nil, httperror.UnprocessableEntity(
+				action+".mismatch",
+				"The order must list every image of the item exactly once",
+				map[string][]string{
+					"missing": mismatchErr.missing,
+					"unknown": mismatchErr.unknown,
+				},
+			)
+		case errors.Is(err, errItemImageNotFound):
+			return nil, httperror.NotFound(
+				action+".image_not_found",
+				"Image not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			action+".failed",
+			"Failed to change item images",
+			nil,
+		)
+	}
+
+	return images, nil
+}
+
+// publishEvents announces what changed between the images before and after a
+// change. Unchanged arrangements publish nothing.
+func (e itemImagesEditor) publishEvents(ctx context.Context, item domain.Item, before, after []domain.ItemImage) error {
+	imageIDs := itemImageIDs(after)
+	if !slices.Equal(itemImageIDs(before), imageIDs) {
+		if err := publishItemImagesReordered(ctx, e.eventPublisher, item, imageIDs); err != nil {
+			return err
+		}
+	}
+
+	previous, hadPrimary := primaryItemImage(before)
+	primary, ok := primaryItemImage(after)
+	if !ok || (hadPrimary && previous.ID == primary.ID) {
+		return nil
+	}
+
+	var previousID *string
+	if hadPrimary {
+		previousID = &previous.ID
+	}
+
+	return publishPrimaryImageChanged(ctx, e.eventPublisher, item, primary, previousID)
+}
+
+// checkImageOrder returns an *imageOrderMismatchError unless imageIDs lists
+// every one of images exactly once
+func checkImageOrder(images []domain.ItemImage, imageIDs []string) error {
+	mismatch := &imageOrderMismatchError{missing: []string{}, unknown: []string{}}
+
+	existing := itemImageIDs(images)
+	for _, id := range existing {
+		if !slices.Contains(imageIDs, id) {
+			mismatch.missing = append(mismatch.missing, id)
+		}
+	}
+	for _, id := range imageIDs {
+		if !slices.Contains(existing, id) {
+			mismatch.unknown = append(mismatch.unknown, id)
+		}
+	}
+
+	if len(mismatch.missing) > 0 || len(mismatch.unknown) > 0 {
+		return mismatch
+	}
+
+	return nil
+}
+
+// itemImageSlots counts the images of an item and its pending uploads, which
+// both count towards the image limit
+func itemImageSlots(ctx context.Context, repository Repository, itemID string) (int, error) {
+	images, err := repository.CountItemImages(ctx, itemID)
+	if err != nil {
+		return 0, err
+	}
+
+	uploads, err := repository.CountPendingImageUploads(ctx, itemID, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return images + uploads, nil
+}
+
+// checkImageLimit returns an error coded code when adding images to an item
+// with count of them exceeds maxImages. 0 means no limit.
+func checkImageLimit(code string, maxImages, count, adding int) error {
+	if maxImages <= 0 || count+adding <= maxImages {
+		return nil
+	}
+
+	return httperror.UnprocessableEntity(code, fmt.Sprintf("Items can have at most %d images", maxImages),
+		map[string]int{
+			"images":    count,
+			"uploading": adding,
+			"max":       maxImages,
+		})
+}
+
+func itemImageIDs(images []domain.ItemImage) []string {
+	ids := make([]string, len(images))
+	for i, image := range images {
+		ids[i] = image.ID
+	}
+
+	return ids
+}
+
+func primaryItemImage(images []domain.ItemImage) (domain.ItemImage, bool) {
+	for _, image := range images {
+		if image.IsPrimary {
+			return image, true
+		}
+	}
+
+	return domain.ItemImage{}, false
+}
+
+func publishItemImagesReordered(ctx context.Context, eventPublisher events.Publisher, item domain.Item, imageIDs []string) error {
+	eventPayload := events.ItemImagesReorderedPayload{
+		ItemID:      item.ID,
+		SellerID:    item.SellerID,
+		ImageIDs:    imageIDs,
+		ReorderedAt: time.Now().UTC(),
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemImagesReorderedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.images.reordered event: %w", err)
+	}
+
+	return nil
+}
+
+func publishPrimaryImageChanged(ctx context.Context, eventPublisher events.Publisher, item domain.Item, primary domain.ItemImage, previousID *string) error {
+	eventPayload := events.ItemPrimaryImageChangedPayload{
+		ItemID:          item.ID,
+		SellerID:        item.SellerID,
+		ImageID:         primary.ID,
+		ImageURL:        primary.ImageURL,
+		PreviousImageID: previousID,
+		ChangedAt:       time.Now().UTC(),
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemPrimaryImageChangedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.primary_image.changed event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"auction/domain"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckImageOrder(t *testing.T) {
+	images := []domain.ItemImage{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	tests := []struct {
+		name        string
+		imageIDs    []string
+		wantMissing []string
+		wantUnknown []string
+	}{
+		{"same order", []string{"a", "b", "c"}, nil, nil},
+		{"new order", []string{"c", "a", "b"}, nil, nil},
+		{"missing", []string{"c", "a"}, []string{"b"}, []string{}},
+		{"unknown", []string{"a", "b", "c", "d"}, []string{}, []string{"d"}},
+		{"both", []string{"d", "a", "b"}, []string{"c"}, []string{"d"}},
+	}
+
+	for _, tt := range tests {
+		err := checkImageOrder(images, tt.imageIDs)
+
+		if tt.wantMissing == nil {
+			if err != nil {
+				t.Errorf("%s: err = %v, want nil", tt.name, err)
+			}
+			continue
+		}
+
+		var mismatch *imageOrderMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Errorf("%s: err = %v, want an imageOrderMismatchError", tt.name, err)
+			continue
+		}
+		if !slices.Equal(mismatch.missing, tt.wantMissing) || !slices.Equal(mismatch.unknown, tt.wantUnknown) {
+			t.Errorf("%s: missing %v, unknown %v; want %v, %v", tt.name, mismatch.missing, mismatch.unknown, tt.wantMissing, tt.wantUnknown)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"fmt"
+	"time"
+)
+
+// SellerQuestionFilter selects the questions asked on the items of a seller
+type SellerQuestionFilter struct {
+	SellerID   string
+	ItemID     string // Optional
+	Unanswered bool   // Only questions without a visible answer
+}
+
+// SellerQuestion is a question with the item it was asked on
+type SellerQuestion struct {
+	domain.ItemComment
+	ItemName    string `json:"item_name" db:"item_name"`
+	AnswerCount int    `json:"answer_count" db:"answer_count"`
+}
+
+// checkCommentKind enforces the question and answer flow: questions start a
+// thread and are asked by anyone but the seller, answers reply to a question
+// and are written by the seller of the item
+func checkCommentKind(kind domain.CommentKind, item domain.Item, parent *domain.ItemComment, userID string) error {
+	switch kind {
+	case domain.CommentKindQuestion:
+		if parent != nil {
+			return httperror.UnprocessableEntity(
+				"comments.create.question_reply",
+				"Questions cannot be replies",
+				nil,
+			)
+		}
+
+		if item.SellerID == userID {
+			return httperror.UnprocessableEntity(
+				"comments.create.own_item_question",
+				"Sellers cannot ask questions on their own items",
+				nil,
+			)
+		}
+
+	case domain.CommentKindAnswer:
+		if parent == nil || !parent.IsQuestion() {
+			return httperror.UnprocessableEntity(
+				"comments.create.answer_without_question",
+				"Answers must reply to a question",
+				nil,
+			)
+		}
+
+		if item.SellerID != userID {
+			return httperror.Forbidden(
+				"comments.create.answer_forbidden",
+				"Only the seller can answer questions",
+				nil,
+			)
+		}
+	}
+
+	return nil
+}
+
+func publishQuestionAnswered(ctx context.Context, eventPublisher events.Publisher, question domain.ItemComment, answer domain.ItemComment) error {
+	eventPayload := events.ItemQuestionAnsweredPayload{
+		QuestionID: question.ID,
+		AnswerID:   answer.ID,
+		ItemID:     answer.ItemID,
+		SellerID:   answer.UserID,
+		AskerID:    question.UserID,
+		Question:   question.Content,
+		Answer:     answer.Content,
+		AnsweredAt: time.Now().UTC(),
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemQuestionAnsweredEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.question.answered event: %w", err)
+	}
+
+	return nil
+}
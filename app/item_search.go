@@ -0,0 +1,44 @@
+package app
+
+import (
+	"auction/domain"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ItemSearch is a full-text search over item name, description and attribute values
+type ItemSearch struct {
+	Query string
+
+	// Statuses of the items searched
+	Statuses []domain.ItemStatus
+
+	// Text search configurations of the items searched, e.g. "english". The
+	// query is parsed in the language of each item.
+	Languages []string
+}
+
+// itemLanguage returns the text search configuration of an item: language
+// when it is one of languages, otherwise an error, or the first language when
+// nil
+func itemLanguage(language *string, languages []string) (*string, error) {
+	if language == nil {
+		return &languages[0], nil
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(*language))
+	if !slices.Contains(languages, normalized) {
+		return nil, fmt.Errorf("unsupported language %q", *language)
+	}
+
+	return &normalized, nil
+}
+
+// ItemSearchResult is an item matching an ItemSearch, with its rank and highlighted text
+type ItemSearchResult struct {
+	Item          domain.Item
+	Rank          float64
+	NameHighlight string
+	Snippet       string
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"errors"
+)
+
+// statusConflict converts an illegal status transition into a 409 response
+// carrying the current and requested status.
+func statusConflict(code string, err error) error {
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		return httperror.Conflict(
+			code,
+			transitionErr.Error(),
+			map[string]any{
+				"currentStatus":   transitionErr.From,
+				"requestedStatus": transitionErr.To,
+			},
+		).WithCause(transitionErr)
+	}
+
+	return httperror.InternalServerError(code, "Failed to change item status", nil)
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"auction/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// PublicItem is the item as shown to everyone but its seller. The reserve
+// amount is hidden; bidders only learn whether it has been met.
+type PublicItem struct {
+	domain.Item
+
+	// Shadows domain.Item.ReservePrice, always nil so it is omitted from the JSON
+	ReservePrice *decimal.Decimal `json:"reservePrice,omitempty"`
+
+	HasReserve bool `json:"hasReserve"`
+	ReserveMet bool `json:"reserveMet"`
+}
+
+func NewPublicItem(item domain.Item) PublicItem {
+	return PublicItem{
+		Item:       item,
+		HasReserve: item.ReservePrice != nil,
+		ReserveMet: item.IsReserveMet(),
+	}
+}
+
+func NewPublicItems(items []domain.Item) []PublicItem {
+	publicItems := make([]PublicItem, 0, len(items))
+	for _, item := range items {
+		publicItems = append(publicItems, NewPublicItem(item))
+	}
+
+	return publicItems
+}
+
+// OwnerItem is the seller's view of their own item, including the reserve amount
+type OwnerItem struct {
+	domain.Item
+
+	ReserveMet bool `json:"reserveMet"`
+}
+
+func NewOwnerItem(item domain.Item) OwnerItem {
+	return OwnerItem{
+		Item:       item,
+		ReserveMet: item.IsReserveMet(),
+	}
+}
+
+// ItemImageWithVariants is an image with the renditions the worker rendered
+// of it, empty until the image is processed
+type ItemImageWithVariants struct {
+	domain.ItemImage
+	Variants []domain.ItemImageVariant `json:"variants"`
+}
+
+func NewItemImagesWithVariants(images []domain.ItemImage, variants []domain.ItemImageVariant) []ItemImageWithVariants {
+	byImage := make(map[string][]domain.ItemImageVariant)
+	for _, variant := range variants {
+		byImage[variant.ImageID] = append(byImage[variant.ImageID], variant)
+	}
+
+	result := make([]ItemImageWithVariants, 0, len(images))
+	for _, image := range images {
+		imageVariants := byImage[image.ID]
+		if imageVariants == nil {
+			imageVariants = []domain.ItemImageVariant{}
+		}
+
+		result = append(result, ItemImageWithVariants{
+			ItemImage: image,
+			Variants:  imageVariants,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	errParentCategoryNotFound = errors.New("parent category not found")
+	errParentCategoryArchived = errors.New("parent category is archived")
+)
+
+type MoveCategoryHandler struct {
+	repository Repository
+}
+
+func NewMoveCategoryHandler(repository Repository) *MoveCategoryHandler {
+	return &MoveCategoryHandler{
+		repository: repository,
+	}
+}
+
+type MoveCategoryRequest struct {
+	ID       string  `params:"id" validate:"required,uuid"`
+	ParentID *string `json:"parentId" validate:"omitempty,uuid"` // null moves the category to the root
+}
+
+type MoveCategoryResponse struct {
+	Category domain.Category `json:"category"`
+}
+
+// Handle re-parents a category together with its subtree
+func (h MoveCategoryHandler) Handle(ctx context.Context, req *MoveCategoryRequest) (*MoveCategoryResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"category.move.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.move.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	var moved domain.Category
+	err := h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+
+		category, err := h.repository.GetCategoryByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		var parentPath []domain.Category
+		if req.ParentID != nil {
+			parentPath, err = h.repository.GetCategoryPath(ctx, *req.ParentID)
+			if err != nil {
+				return err
+			}
+			if len(parentPath) == 0 {
+				return errParentCategoryNotFound
+			}
+			if parentPath[len(parentPath)-1].IsArchived() {
+				return errParentCategoryArchived
+			}
+		}
+
+		if err := category.MoveTo(req.ParentID, parentPath); err != nil {
+			return err
+		}
+
+		moved, err = h.repository.MoveCategory(ctx, category.ID, category.ParentID)
+
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, httperror.NotFound(
+				"category.move.not_found",
+				"Category not found",
+				nil,
+			)
+		case errors.Is(err, errParentCategoryNotFound):
+			return nil, httperror.NotFound(
+				"category.move.parent_not_found",
+				"Parent category not found",
+				nil,
+			)
+		case errors.Is(err, errParentCategoryArchived):
+			return nil, httperror.Conflict(
+				"category.move.parent_archived",
+				"Categories cannot be moved under an archived category",
+				nil,
+			)
+		case errors.Is(err, domain.ErrCategoryCycle):
+			return nil, httperror.Conflict(
+				"category.move.cycle",
+				"A category cannot be moved under itself or one of its descendants",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.move.failed",
+			"Failed to move category",
+			nil,
+		)
+	}
+
+	return &MoveCategoryResponse{
+		Category: moved,
+	}, nil
+}
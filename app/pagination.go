@@ -0,0 +1,194 @@
+package app
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page sizes of list endpoints
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// clampPageSize returns the page size to use for a requested one, which is
+// raised to defaultPageSize and capped at maxPageSize
+func clampPageSize(requested int) int {
+	return min(max(requested, defaultPageSize), maxPageSize)
+}
+
+// CursorValue is the type of the sort key of a listing, which the repository
+// casts the cursor value to
+type CursorValue int
+
+const (
+	CursorTime    CursorValue = iota // RFC 3339 timestamp
+	CursorInt                        // 32-bit integer
+	CursorDecimal                    // Plain decimal number
+)
+
+var cursorDecimalPattern = regexp.MustCompile(`^-?[0-9]{1,20}(\.[0-9]{1,20})?$`)
+
+// valid reports whether value is a sort key of type v
+func (v CursorValue) valid(value string) bool {
+	switch v {
+	case CursorTime:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case CursorInt:
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	case CursorDecimal:
+		return cursorDecimalPattern.MatchString(value)
+	default:
+		return false
+	}
+}
+
+// Cursor is a position in a keyset-paginated listing: the sort key and id of
+// a row. Clients receive it as an opaque token.
+type Cursor struct {
+	Value    string `json:"v"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"` // Read the page before the row instead of after it
+	Sort     string `json:"s,omitempty"` // Sort order the cursor was issued for
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor token issued for the given sort order, whose
+// sort key is of type value. Tokens are client input: the value and the id
+// are checked before they reach a query.
+func DecodeCursor(token string, sort string, value CursorValue) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if cursor.Sort != sort || !value.valid(cursor.Value) {
+		return nil, ErrInvalidCursor
+	}
+
+	// Only the canonical form, which is what cursors are issued with
+	if id, err := uuid.Parse(cursor.ID); err != nil || id.String() != cursor.ID {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// PageRequest selects a page of a keyset-paginated listing. Without a cursor
+// it selects the first page.
+type PageRequest struct {
+	Cursor *Cursor
+	Limit  int
+}
+
+// Backward reports whether the page before the cursor is requested
+func (p PageRequest) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// Pagination describes the page returned by a list endpoint. Page and the
+// totals are set for page/pageSize requests; cursor requests only count the
+// total when asked to.
+type Pagination struct {
+	Page       int     `json:"page,omitempty"`
+	PageSize   int     `json:"pageSize"`
+	TotalItems *int    `json:"totalItems,omitempty"`
+	TotalPages *int    `json:"totalPages,omitempty"`
+	NextCursor *string `json:"nextCursor"`
+	PrevCursor *string `json:"prevCursor"`
+}
+
+// cursorKey returns the sort key and id of a row
+type cursorKey[T any] func(row T) (value string, id string)
+
+// offsetPagination builds the pagination of a page/pageSize request. The
+// cursors let clients continue with keyset pagination from this page.
+func offsetPagination[T any](rows []T, page, pageSize, totalItems int, sort string, key cursorKey[T]) Pagination {
+	totalPages := (totalItems + pageSize - 1) / pageSize
+
+	pagination := Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: &totalItems,
+		TotalPages: &totalPages,
+	}
+
+	if len(rows) == 0 {
+		return pagination
+	}
+
+	if (page-1)*pageSize+len(rows) < totalItems {
+		pagination.NextCursor = newCursor(rows[len(rows)-1], false, sort, key)
+	}
+	if page > 1 {
+		pagination.PrevCursor = newCursor(rows[0], true, sort, key)
+	}
+
+	return pagination
+}
+
+// cursorPagination builds the pagination of a cursor request. more reports
+// whether rows exist beyond the page in the direction it was read.
+func cursorPagination[T any](rows []T, req PageRequest, more bool, totalItems *int, sort string, key cursorKey[T]) Pagination {
+	pagination := Pagination{
+		PageSize:   req.Limit,
+		TotalItems: totalItems,
+	}
+
+	if totalItems != nil {
+		totalPages := (*totalItems + req.Limit - 1) / req.Limit
+		pagination.TotalPages = &totalPages
+	}
+
+	if len(rows) == 0 {
+		return pagination
+	}
+
+	// Reading forward, a previous page exists whenever we started from a cursor;
+	// reading backward, a next page always exists
+	hasNext, hasPrev := more, req.Cursor != nil
+	if req.Backward() {
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		pagination.NextCursor = newCursor(rows[len(rows)-1], false, sort, key)
+	}
+	if hasPrev {
+		pagination.PrevCursor = newCursor(rows[0], true, sort, key)
+	}
+
+	return pagination
+}
+
+func newCursor[T any](row T, backward bool, sort string, key cursorKey[T]) *string {
+	value, id := key(row)
+	token := Cursor{Value: value, ID: id, Backward: backward, Sort: sort}.Encode()
+
+	return &token
+}
+
+// formatCursorTime formats a timestamp sort key without losing precision
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
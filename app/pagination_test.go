@@ -0,0 +1,81 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+const testCursorID = "0b7e4f5c-8d3a-4a7e-9c1f-2e6d5b4a3c21"
+
+func TestClampPageSize(t *testing.T) {
+	tests := []struct {
+		requested int
+		want      int
+	}{
+		{0, defaultPageSize},
+		{-5, defaultPageSize},
+		{25, 25},
+		{maxPageSize, maxPageSize},
+		{1000000, maxPageSize},
+	}
+
+	for _, tt := range tests {
+		if got := clampPageSize(tt.requested); got != tt.want {
+			t.Errorf("clampPageSize(%d) = %d, want %d", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		cursor Cursor
+		value  CursorValue
+	}{
+		{Cursor{Value: "2026-03-01T12:00:00.123456789Z", ID: testCursorID, Sort: "newest"}, CursorTime},
+		{Cursor{Value: "42", ID: testCursorID, Backward: true, Sort: "most_bids"}, CursorInt},
+		{Cursor{Value: "-12.50", ID: testCursorID, Sort: "price_asc"}, CursorDecimal},
+		{Cursor{Value: "2026-03-01T12:00:00Z", ID: testCursorID}, CursorTime},
+	}
+
+	for _, tt := range tests {
+		decoded, err := DecodeCursor(tt.cursor.Encode(), tt.cursor.Sort, tt.value)
+		if err != nil {
+			t.Errorf("%+v: DecodeCursor: %v", tt.cursor, err)
+			continue
+		}
+		if *decoded != tt.cursor {
+			t.Errorf("got %+v, want %+v", *decoded, tt.cursor)
+		}
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	encode := func(value, id, sort string) string {
+		return Cursor{Value: value, ID: id, Sort: sort}.Encode()
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		value CursorValue
+	}{
+		{"not base64", "not a cursor!", CursorTime},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("{")), CursorTime},
+		{"other sort", encode("2026-03-01T12:00:00Z", testCursorID, "ending_soon"), CursorTime},
+		{"bad time", encode("yesterday", testCursorID, "newest"), CursorTime},
+		{"bad int", encode("1.5", testCursorID, "newest"), CursorInt},
+		{"int overflow", encode("4294967296", testCursorID, "newest"), CursorInt},
+		{"bad decimal", encode("1e9", testCursorID, "newest"), CursorDecimal},
+		{"injected decimal", encode("1 OR 1=1", testCursorID, "newest"), CursorDecimal},
+		{"bad id", encode("2026-03-01T12:00:00Z", "42", "newest"), CursorTime},
+		{"non-canonical id", encode("2026-03-01T12:00:00Z", "{"+testCursorID+"}", "newest"), CursorTime},
+		{"empty", "", CursorTime},
+	}
+
+	for _, tt := range tests {
+		if _, err := DecodeCursor(tt.token, "newest", tt.value); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, ErrInvalidCursor)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type RemoveItemCategoriesHandler struct {
+	editor itemCategoriesEditor
+}
+
+func NewRemoveItemCategoriesHandler(repository Repository, eventPublisher events.Publisher) *RemoveItemCategoriesHandler {
+	return &RemoveItemCategoriesHandler{
+		editor: itemCategoriesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type RemoveItemCategoriesRequest struct {
+	ItemID      string   `params:"id" validate:"required,uuid"`
+	CategoryIDs []string `json:"categoryIds" validate:"required,min=1,unique,dive,uuid"`
+}
+
+type RemoveItemCategoriesResponse struct {
+	Categories []domain.Category `json:"categories"`
+}
+
+// Handle unassigns categories from one of the user's items
+func (h RemoveItemCategoriesHandler) Handle(ctx context.Context, req *RemoveItemCategoriesRequest) (*RemoveItemCategoriesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.categories.remove.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.categories.remove.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	categories, err := h.editor.edit(ctx, "item.categories.remove", req.ItemID, nil, func(ctx context.Context, itemID string) ([]string, []string, error) {
+		removed, err := h.editor.repository.RemoveItemCategories(ctx, itemID, req.CategoryIDs)
+
+		return nil, removed, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveItemCategoriesResponse{
+		Categories: categories,
+	}, nil
+}
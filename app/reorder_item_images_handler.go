@@ -0,0 +1,69 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReorderItemImagesHandler struct {
+	editor itemImagesEditor
+}
+
+func NewReorderItemImagesHandler(repository Repository, eventPublisher events.Publisher) *ReorderItemImagesHandler {
+	return &ReorderItemImagesHandler{
+		editor: itemImagesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type ReorderItemImagesRequest struct {
+	ItemID   string   `params:"itemId" validate:"required,uuid"`
+	ImageIDs []string `json:"imageIds" validate:"required,min=1,unique,dive,uuid"`
+}
+
+type ReorderItemImagesResponse struct {
+	Images []domain.ItemImage `json:"images"`
+}
+
+// Handle shows the images of one of the user's items in the requested order.
+// The order lists every image of the item; the primary image is kept.
+func (h ReorderItemImagesHandler) Handle(ctx context.Context, req *ReorderItemImagesRequest) (*ReorderItemImagesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item_images.order.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item_images.order.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	images, err := h.editor.arrange(ctx, "item_images.order", req.ItemID, func(ctx context.Context, images []domain.ItemImage) error {
+		if err := checkImageOrder(images, req.ImageIDs); err != nil {
+			return err
+		}
+
+		return h.editor.repository.ReorderItemImages(ctx, req.ItemID, req.ImageIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReorderItemImagesResponse{
+		Images: images,
+	}, nil
+}
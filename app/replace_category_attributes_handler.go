@@ -0,0 +1,133 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReplaceCategoryAttributesHandler struct {
+	repository Repository
+}
+
+func NewReplaceCategoryAttributesHandler(repository Repository) *ReplaceCategoryAttributesHandler {
+	return &ReplaceCategoryAttributesHandler{
+		repository: repository,
+	}
+}
+
+type CategoryAttributeDefinition struct {
+	Key        string               `json:"key" validate:"required,max=100"`
+	Type       domain.AttributeType `json:"type" validate:"required,oneof=string number enum bool date"`
+	Required   bool                 `json:"required"`
+	EnumValues []string             `json:"enumValues,omitempty" validate:"required_if=Type enum,excluded_unless=Type enum,unique,dive,required"`
+	Unit       *string              `json:"unit,omitempty" validate:"omitempty,max=32"`
+}
+
+type ReplaceCategoryAttributesRequest struct {
+	ID         string                        `params:"id" validate:"required,uuid"`
+	Attributes []CategoryAttributeDefinition `json:"attributes" validate:"required,dive"`
+}
+
+type ReplaceCategoryAttributesResponse struct {
+	Attributes []domain.CategoryAttribute `json:"attributes"`
+}
+
+// Handle replaces the attribute schema of a category. Attributes that items
+// already have are not revalidated; the schema applies to attributes added
+// from now on.
+func (h ReplaceCategoryAttributesHandler) Handle(ctx context.Context, req *ReplaceCategoryAttributesRequest) (*ReplaceCategoryAttributesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"category.attributes.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.attributes.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	attributes, err := req.definitions()
+	if err != nil {
+		return nil, httperror.BadRequest(
+			"category.attributes.validation_failed",
+			"Validation failed for the request",
+			err.Error(),
+		)
+	}
+
+	if _, err := h.repository.GetCategoryByID(ctx, req.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.attributes.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.attributes.failed",
+			"Failed to get category",
+			nil,
+		)
+	}
+
+	saved, err := h.repository.ReplaceCategoryAttributes(ctx, req.ID, attributes)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"category.attributes.failed",
+			"Failed to save category attributes",
+			nil,
+		)
+	}
+
+	return &ReplaceCategoryAttributesResponse{
+		Attributes: saved,
+	}, nil
+}
+
+// definitions converts the request into attribute definitions with normalized keys
+func (r ReplaceCategoryAttributesRequest) definitions() ([]domain.CategoryAttribute, error) {
+	attributes := make([]domain.CategoryAttribute, 0, len(r.Attributes))
+	seen := make(map[string]bool, len(r.Attributes))
+
+	for _, definition := range r.Attributes {
+		key := domain.NormalizeAttributeKey(definition.Key)
+		if key == "" {
+			return nil, fmt.Errorf("attribute keys must not be blank")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("attribute key %q is defined more than once", key)
+		}
+		seen[key] = true
+
+		enumValues := definition.EnumValues
+		if enumValues == nil {
+			enumValues = []string{}
+		}
+
+		attributes = append(attributes, domain.CategoryAttribute{
+			CategoryID: r.ID,
+			Key:        key,
+			Type:       definition.Type,
+			Required:   definition.Required,
+			EnumValues: enumValues,
+			Unit:       definition.Unit,
+		})
+	}
+
+	return attributes, nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReplaceItemAttributesHandler struct {
+	editor itemAttributesEditor
+}
+
+func NewReplaceItemAttributesHandler(repository Repository, eventPublisher events.Publisher) *ReplaceItemAttributesHandler {
+	return &ReplaceItemAttributesHandler{
+		editor: itemAttributesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type ReplaceItemAttributesRequest struct {
+	ItemID     string              `params:"itemId" validate:"required,uuid"`
+	Attributes []AttributeKeyValue `json:"attributes" validate:"required,dive"`
+}
+
+type ReplaceItemAttributesResponse struct {
+	Attributes []domain.ItemAttribute `json:"attributes"`
+	Added      []domain.ItemAttribute `json:"added"`
+	Updated    []domain.ItemAttribute `json:"updated"`
+	Removed    []domain.ItemAttribute `json:"removed"`
+}
+
+// Handle makes the requested attributes the full attribute set of one of the
+// user's items. Attributes are matched by key: kept keys are updated in place,
+// missing ones are removed. An empty list removes all of them.
+func (h ReplaceItemAttributesHandler) Handle(ctx context.Context, req *ReplaceItemAttributesRequest) (*ReplaceItemAttributesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.attributes.replace.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.attributes.replace.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	desired := make([]domain.ItemAttribute, len(req.Attributes))
+	for i, attr := range req.Attributes {
+		desired[i] = domain.ItemAttribute{
+			ItemID: req.ItemID,
+			Key:    attr.Key,
+			Value:  attr.Value,
+		}
+	}
+
+	attributes, changes, err := h.editor.edit(ctx, "item.attributes.replace", req.ItemID, func(schema domain.AttributeSchema, existing []domain.ItemAttribute) (domain.ItemAttributeChanges, error) {
+		// The request is the full set, so required keys must be in it
+		normalized, violations := schema.Validate(desired, nil, true)
+		if len(violations) > 0 {
+			return domain.ItemAttributeChanges{}, &attributeViolationsError{violations: violations}
+		}
+
+		return domain.DiffItemAttributes(existing, normalized), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceItemAttributesResponse{
+		Attributes: attributes,
+		Added:      changes.Added,
+		Updated:    changes.Updated,
+		Removed:    changes.Removed,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReplaceItemCategoriesHandler struct {
+	editor itemCategoriesEditor
+}
+
+func NewReplaceItemCategoriesHandler(repository Repository, eventPublisher events.Publisher) *ReplaceItemCategoriesHandler {
+	return &ReplaceItemCategoriesHandler{
+		editor: itemCategoriesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type ReplaceItemCategoriesRequest struct {
+	ItemID      string   `params:"id" validate:"required,uuid"`
+	CategoryIDs []string `json:"categoryIds" validate:"required,unique,dive,uuid"`
+}
+
+type ReplaceItemCategoriesResponse struct {
+	Categories []domain.Category `json:"categories"`
+}
+
+// Handle makes the requested categories the only categories of one of the
+// user's items. An empty list removes all of them.
+func (h ReplaceItemCategoriesHandler) Handle(ctx context.Context, req *ReplaceItemCategoriesRequest) (*ReplaceItemCategoriesResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.categories.replace.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.categories.replace.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	categories, err := h.editor.edit(ctx, "item.categories.replace", req.ItemID, req.CategoryIDs, func(ctx context.Context, itemID string) ([]string, []string, error) {
+		return h.editor.repository.ReplaceItemCategories(ctx, itemID, req.CategoryIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceItemCategoriesResponse{
+		Categories: categories,
+	}, nil
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// reportedReason is the moderation reason of comments held for review because of reports
+const reportedReason = "reported"
+
+type ReportCommentHandler struct {
+	repository      Repository
+	eventPublisher  events.Publisher
+	reportThreshold int
+}
+
+// NewReportCommentHandler creates the handler. Visible comments are held for
+// review once they have reportThreshold reports; zero or less never holds them.
+func NewReportCommentHandler(repository Repository, eventPublisher events.Publisher, reportThreshold int) *ReportCommentHandler {
+	return &ReportCommentHandler{
+		repository:      repository,
+		eventPublisher:  eventPublisher,
+		reportThreshold: reportThreshold,
+	}
+}
+
+type ReportCommentRequest struct {
+	ItemID    string  `params:"itemId" validate:"required,uuid"`
+	CommentID string  `params:"commentId" validate:"required,uuid"`
+	Reason    string  `json:"reason" validate:"required,oneof=spam abuse off_topic other"`
+	Details   *string `json:"details" validate:"omitnil,max=1000"`
+}
+
+type ReportCommentResponse struct {
+	Created bool `json:"created"` // false when the user had already reported the comment
+}
+
+// Handle records the current user's report of a comment
+func (h *ReportCommentHandler) Handle(ctx context.Context, req *ReportCommentRequest) (*ReportCommentResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comment.report.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comment.report.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	comment, err := h.repository.GetCommentByID(ctx, req.CommentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError(
+			"comment.report.failed",
+			"Failed to get comment",
+			nil,
+		)
+	}
+	if err != nil || comment.ItemID != req.ItemID || comment.IsDeleted() {
+		return nil, httperror.NotFound(
+			"comment.report.not_found",
+			"Comment not found",
+			nil,
+		)
+	}
+
+	userID := ctx.Value("UserID").(string)
+
+	if comment.UserID == userID {
+		return nil, httperror.UnprocessableEntity(
+			"comment.report.own_comment",
+			"You cannot report your own comment",
+			nil,
+		)
+	}
+
+	report := domain.ItemCommentReport{
+		CommentID:  comment.ID,
+		ReporterID: userID,
+		Reason:     domain.CommentReportReason(req.Reason),
+		Details:    req.Details,
+	}
+
+	var created bool
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var reports int
+		var err error
+		created, reports, err = h.repository.CreateCommentReport(ctx, report)
+		if err != nil || !created {
+			return err
+		}
+
+		if err := h.publishEvent(ctx, comment, report, reports); err != nil {
+			return err
+		}
+
+		if h.reportThreshold <= 0 || reports < h.reportThreshold || !comment.IsVisible() {
+			return nil
+		}
+
+		return h.holdForReview(ctx, comment)
+	})
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"comment.report.failed",
+			"Failed to report comment",
+			nil,
+		)
+	}
+
+	return &ReportCommentResponse{
+		Created: created,
+	}, nil
+}
+
+// holdForReview hides a comment that was reported too often until a moderator
+// releases it
+func (h *ReportCommentHandler) holdForReview(ctx context.Context, comment domain.ItemComment) error {
+	previous := comment.Status
+	reason := reportedReason
+
+	if _, err := comment.Moderate(domain.CommentStatusPending, nil, &reason, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	held, err := h.repository.UpdateCommentStatus(ctx, comment, previous)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Moderated meanwhile; that decision stands
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return publishCommentModerated(ctx, h.eventPublisher, previous, held)
+}
+
+func (h *ReportCommentHandler) publishEvent(ctx context.Context, comment domain.ItemComment, report domain.ItemCommentReport, reports int) error {
+	eventPayload := events.ItemCommentReportedPayload{
+		ID:          comment.ID,
+		ItemID:      comment.ItemID,
+		AuthorID:    comment.UserID,
+		ReporterID:  report.ReporterID,
+		Reason:      string(report.Reason),
+		ReportCount: reports,
+		ReportedAt:  time.Now().UTC(),
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemCommentReportedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.comment.reported event: %w", err)
+	}
+
+	return nil
+}
@@ -3,34 +3,93 @@ package app
 import (
 	"auction/domain"
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrOptimisticLock is returned by Update and UpdateUserItem when the item's
+// version no longer matches the stored row, i.e. someone else updated it first.
+var ErrOptimisticLock = errors.New("optimistic lock failed")
+
 type Repository interface {
 	Close() error
-	GetItems(ctx context.Context, limit, offset int) ([]domain.Item, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	GetItems(ctx context.Context, filter ItemFilter, limit, offset int) ([]domain.Item, error)
+	GetItemsPage(ctx context.Context, filter ItemFilter, page PageRequest) ([]domain.Item, bool, error)
 	GetCategories(ctx context.Context, limit, offset int) ([]domain.Category, error)
+	GetCategoriesPage(ctx context.Context, page PageRequest) ([]domain.Category, bool, error)
 	GetItem(ctx context.Context, id string) (domain.Item, error)
 	GetUserItem(ctx context.Context, id string, userID string) (domain.Item, error)
 	DeleteItem(ctx context.Context, id string, userID string) error
-	CountItems(ctx context.Context) (int, error)
+	CountItems(ctx context.Context, filter ItemFilter) (int, error)
+	GetItemFacets(ctx context.Context, filter ItemFilter, options ItemFacetOptions) (ItemFacets, error)
+	SearchItems(ctx context.Context, search ItemSearch, limit, offset int) ([]ItemSearchResult, error)
+	CountSearchItems(ctx context.Context, search ItemSearch) (int, error)
 	CountCategories(ctx context.Context) (int, error)
 	Create(ctx context.Context, req *CreateItemRequest) (domain.Item, error)
 	UpdateUserItem(ctx context.Context, item domain.Item, userID string) error
 	Update(ctx context.Context, item domain.Item) error
+	ClaimExpiredItems(ctx context.Context, before time.Time, limit int) ([]domain.Item, error)
+	ClaimScheduledItems(ctx context.Context, before time.Time, limit int) ([]domain.Item, error)
 	GetCategoryByID(ctx context.Context, id string) (domain.Category, error)
 	GetCategoriesByItemID(ctx context.Context, itemID string) ([]domain.Category, error)
+	GetCategoriesByIDs(ctx context.Context, ids []string) ([]domain.Category, error)
+	AddItemCategories(ctx context.Context, itemID string, categoryIDs []string) ([]string, error)
+	RemoveItemCategories(ctx context.Context, itemID string, categoryIDs []string) ([]string, error)
+	ReplaceItemCategories(ctx context.Context, itemID string, categoryIDs []string) (added []string, removed []string, err error)
+	GetCategoryAttributes(ctx context.Context, categoryIDs []string) ([]domain.CategoryAttribute, error)
+	ReplaceCategoryAttributes(ctx context.Context, categoryID string, attributes []domain.CategoryAttribute) ([]domain.CategoryAttribute, error)
+	GetCategoryPath(ctx context.Context, id string) ([]domain.Category, error)
+	GetCategoryTree(ctx context.Context, includeArchived bool) ([]domain.Category, error)
+	CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error)
+	UpdateCategory(ctx context.Context, category domain.Category) (domain.Category, error)
+	MoveCategory(ctx context.Context, id string, parentID *string) (domain.Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+	CountCategoryReferences(ctx context.Context, id string) (children int, items int, err error)
+	LockCategoryTree(ctx context.Context) error
 	GetItemCommentsByItemID(ctx context.Context, itemID string, page, pageSize int) ([]domain.ItemComment, error)
+	GetItemCommentsPage(ctx context.Context, itemID string, page PageRequest) ([]domain.ItemComment, bool, error)
 	CountItemComments(ctx context.Context, itemID string) (int, error)
-	CreateComment(ctx context.Context, itemID string, comment string, userID string, parentID *string) (domain.ItemComment, error)
-	DeleteComment(ctx context.Context, id string) error
+	GetRootCommentsByItemID(ctx context.Context, itemID string, page, pageSize int) ([]domain.ItemComment, error)
+	GetRootCommentsPage(ctx context.Context, itemID string, page PageRequest) ([]domain.ItemComment, bool, error)
+	CountRootComments(ctx context.Context, itemID string) (int, error)
+	GetCommentReplies(ctx context.Context, rootIDs []string, limit int) (map[string]CommentReplies, error)
+	GetCommentRepliesPage(ctx context.Context, commentID string, page PageRequest) ([]CommentReply, bool, error)
+	CountCommentReplies(ctx context.Context, commentID string) (int, error)
+	CreateComment(ctx context.Context, comment domain.ItemComment) (domain.ItemComment, error)
+	UpdateComment(ctx context.Context, id string, content string, status domain.CommentStatus, editedBy string) (domain.ItemComment, error)
+	UpdateCommentStatus(ctx context.Context, comment domain.ItemComment, previous domain.CommentStatus) (domain.ItemComment, error)
+	CreateCommentReport(ctx context.Context, report domain.ItemCommentReport) (created bool, reports int, err error)
+	GetCommentsByStatus(ctx context.Context, status domain.CommentStatus, page, pageSize int) ([]ReviewedComment, error)
+	CountCommentsByStatus(ctx context.Context, status domain.CommentStatus) (int, error)
+	GetSellerQuestions(ctx context.Context, filter SellerQuestionFilter, page, pageSize int) ([]SellerQuestion, error)
+	CountSellerQuestions(ctx context.Context, filter SellerQuestionFilter) (int, error)
 	GetCommentByID(ctx context.Context, id string) (domain.ItemComment, error)
 	GetItemImages(ctx context.Context, itemID string, page, limit int) ([]domain.ItemImage, error)
+	GetItemImagesPage(ctx context.Context, itemID string, page PageRequest) ([]domain.ItemImage, bool, error)
 	CountItemImages(ctx context.Context, itemID string) (int, error)
 	SaveImage(ctx context.Context, itemID string, imageUrl string) (domain.ItemImage, error)
 	DeleteItemImage(ctx context.Context, itemID string, imageID string) error
 	GetItemImage(ctx context.Context, itemId string, imageId string) (domain.ItemImage, error)
+	GetAllItemImages(ctx context.Context, itemID string) ([]domain.ItemImage, error)
+	GetPrimaryItemImage(ctx context.Context, itemID string) (domain.ItemImage, error)
+	ReorderItemImages(ctx context.Context, itemID string, imageIDs []string) error
+	SetPrimaryItemImage(ctx context.Context, itemID string, imageID string) error
+	LockItemImages(ctx context.Context, itemID string) error
+	CreateImageUpload(ctx context.Context, upload domain.ItemImageUpload) (domain.ItemImageUpload, error)
+	GetImageUpload(ctx context.Context, itemID string, uploadID string) (domain.ItemImageUpload, error)
+	CountPendingImageUploads(ctx context.Context, itemID string, now time.Time) (int, error)
+	CompleteImageUpload(ctx context.Context, upload domain.ItemImageUpload, imageURL string) (domain.ItemImage, error)
+	DeleteImageUpload(ctx context.Context, uploadID string) error
+	ClaimExpiredImageUploads(ctx context.Context, before time.Time, limit int) ([]domain.ItemImageUpload, error)
+	GetItemImageVariants(ctx context.Context, imageIDs []string) ([]domain.ItemImageVariant, error)
+	SaveItemImageVariants(ctx context.Context, variants []domain.ItemImageVariant) ([]domain.ItemImageVariant, error)
 	GetItemAttributes(ctx context.Context, itemID string) ([]domain.ItemAttribute, error)
 	GetItemAttribute(ctx context.Context, itemID string, attributeID string) (domain.ItemAttribute, error)
 	CreateItemAttributes(ctx context.Context, attributes []domain.ItemAttribute) ([]domain.ItemAttribute, error)
+	UpdateItemAttribute(ctx context.Context, attribute domain.ItemAttribute) (domain.ItemAttribute, error)
 	DeleteItemAttribute(ctx context.Context, itemID string, attributeID string) error
+	DeleteItemAttributes(ctx context.Context, itemID string, attributeIDs []string) error
+	LockItemAttributes(ctx context.Context, itemID string) error
+	MarkEventProcessed(ctx context.Context, key string, eventName string) (bool, error)
 }
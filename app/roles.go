@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+	"slices"
+)
+
+// Roles users are authenticated with, see the User-Roles header
+const (
+	RoleAdmin     = "admin"     // Manages categories and their attribute schemas
+	RoleModerator = "moderator" // Hides and releases any comment and sees the moderation queue
+)
+
+// hasRole reports whether the roles the user was authenticated with contain role
+func hasRole(ctx context.Context, role string) bool {
+	roles, _ := ctx.Value("UserRoles").([]string)
+
+	return slices.Contains(roles, role)
+}
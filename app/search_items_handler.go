@@ -0,0 +1,117 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"slices"
+	"strings"
+)
+
+type SearchItemsHandler struct {
+	repository Repository
+	languages  []string
+}
+
+// NewSearchItemsHandler creates the search handler. languages are the text search
+// configurations queries are parsed with; the first one is the default.
+func NewSearchItemsHandler(repository Repository, languages []string) *SearchItemsHandler {
+	return &SearchItemsHandler{
+		repository: repository,
+		languages:  languages,
+	}
+}
+
+type SearchItemsRequest struct {
+	Query    string `query:"q"`
+	Language string `query:"lang"` // Restricts the search to one of the configured languages
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+}
+
+type SearchItemResult struct {
+	Item          PublicItem `json:"item"`
+	Rank          float64    `json:"rank"`
+	NameHighlight string     `json:"nameHighlight"` // Name with matches wrapped in <b></b>
+	Snippet       string     `json:"snippet"`       // Description fragments with matches wrapped in <b></b>
+}
+
+type SearchItemsResponse struct {
+	Results    []SearchItemResult `json:"results"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"pageSize"`
+	TotalItems int                `json:"totalItems"`
+	TotalPages int                `json:"totalPages"`
+}
+
+func (h SearchItemsHandler) Handle(ctx context.Context, req *SearchItemsRequest) (*SearchItemsResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, httperror.BadRequest(
+			"item.search.query_required",
+			"The q query parameter is required",
+			nil,
+		)
+	}
+
+	search := ItemSearch{
+		Query:     query,
+		Statuses:  domain.ListedItemStatuses,
+		Languages: h.languages,
+	}
+
+	if req.Language != "" {
+		if !slices.Contains(h.languages, req.Language) {
+			return nil, httperror.BadRequest(
+				"item.search.unsupported_language",
+				"Unsupported search language",
+				map[string]any{"supportedLanguages": h.languages},
+			)
+		}
+		search.Languages = []string{req.Language}
+	}
+
+	page := max(req.Page, 1)
+	pageSize := clampPageSize(req.PageSize)
+
+	offset := (page - 1) * pageSize
+
+	results, err := h.repository.SearchItems(ctx, search, pageSize, offset)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"item.search.failed",
+			"Failed to search items",
+			nil,
+		)
+	}
+
+	totalItems, err := h.repository.CountSearchItems(ctx, search)
+	if err != nil {
+		return nil, httperror.InternalServerError(
+			"item.search.count_failed",
+			"Failed to count search results",
+			nil,
+		)
+	}
+
+	totalPages := (totalItems + pageSize - 1) / pageSize
+
+	response := &SearchItemsResponse{
+		Results:    make([]SearchItemResult, 0, len(results)),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+
+	for _, result := range results {
+		response.Results = append(response.Results, SearchItemResult{
+			Item:          NewPublicItem(result.Item),
+			Rank:          result.Rank,
+			NameHighlight: result.NameHighlight,
+			Snippet:       result.Snippet,
+		})
+	}
+
+	return response, nil
+}
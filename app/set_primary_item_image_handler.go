@@ -0,0 +1,70 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type SetPrimaryItemImageHandler struct {
+	editor itemImagesEditor
+}
+
+func NewSetPrimaryItemImageHandler(repository Repository, eventPublisher events.Publisher) *SetPrimaryItemImageHandler {
+	return &SetPrimaryItemImageHandler{
+		editor: itemImagesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type SetPrimaryItemImageRequest struct {
+	ItemID  string `params:"itemId" validate:"required,uuid"`
+	ImageID string `params:"imageId" validate:"required,uuid"`
+}
+
+type SetPrimaryItemImageResponse struct {
+	Images []domain.ItemImage `json:"images"`
+}
+
+// Handle makes an image the cover image of one of the user's items. The
+// display order is left as it is.
+func (h SetPrimaryItemImageHandler) Handle(ctx context.Context, req *SetPrimaryItemImageRequest) (*SetPrimaryItemImageResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item_images.primary.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item_images.primary.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	images, err := h.editor.arrange(ctx, "item_images.primary", req.ItemID, func(ctx context.Context, images []domain.ItemImage) error {
+		if !slices.Contains(itemImageIDs(images), req.ImageID) {
+			return errItemImageNotFound
+		}
+
+		return h.editor.repository.SetPrimaryItemImage(ctx, req.ItemID, req.ImageID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetPrimaryItemImageResponse{
+		Images: images,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UnhideCommentHandler struct {
+	moderation commentModeration
+}
+
+func NewUnhideCommentHandler(repository Repository, eventPublisher events.Publisher) *UnhideCommentHandler {
+	return &UnhideCommentHandler{
+		moderation: commentModeration{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type UnhideCommentRequest struct {
+	ItemID    string `params:"itemId" validate:"required,uuid"`
+	CommentID string `params:"commentId" validate:"required,uuid"`
+}
+
+type UnhideCommentResponse struct {
+	Comment domain.ItemComment `json:"comment"`
+}
+
+// Handle makes a hidden comment, or one held for review, visible again
+func (h *UnhideCommentHandler) Handle(ctx context.Context, req *UnhideCommentRequest) (*UnhideCommentResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comment.unhide.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comment.unhide.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	comment, err := h.moderation.moderate(ctx, "comment.unhide", req.ItemID, req.CommentID, domain.CommentStatusVisible, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnhideCommentResponse{
+		Comment: comment,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateCategoryHandler struct {
+	repository Repository
+}
+
+func NewUpdateCategoryHandler(repository Repository) *UpdateCategoryHandler {
+	return &UpdateCategoryHandler{
+		repository: repository,
+	}
+}
+
+// UpdateCategoryRequest changes the fields that are set. The parent is changed
+// with MoveCategoryRequest and archiving with ArchiveCategoryRequest.
+type UpdateCategoryRequest struct {
+	ID          string  `params:"id" validate:"required,uuid"`
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty"`
+	Status      *string `json:"status,omitempty" validate:"omitempty,oneof=active inactive"`
+}
+
+type UpdateCategoryResponse struct {
+	Category domain.Category `json:"category"`
+}
+
+func (h UpdateCategoryHandler) Handle(ctx context.Context, req *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"category.update.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.update.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	category, err := h.repository.GetCategoryByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.update.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.update.failed",
+			"Failed to get category",
+			nil,
+		)
+	}
+
+	if req.Name != nil {
+		category.Name = *req.Name
+	}
+	if req.Description != nil {
+		category.Description = req.Description
+	}
+	if req.Status != nil {
+		category.Status = *req.Status
+	}
+
+	updated, err := h.repository.UpdateCategory(ctx, category)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.NotFound(
+				"category.update.not_found",
+				"Category not found",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"category.update.failed",
+			"Failed to update category",
+			nil,
+		)
+	}
+
+	return &UpdateCategoryResponse{
+		Category: updated,
+	}, nil
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateCommentHandler struct {
+	repository     Repository
+	eventPublisher events.Publisher
+	moderator      CommentModerator
+	editWindow     time.Duration
+}
+
+// NewUpdateCommentHandler creates the handler. Authors can edit comments for
+// editWindow after writing them; zero or less lets them edit at any time.
+// Edits are reviewed by moderator like new comments; nil publishes them.
+func NewUpdateCommentHandler(repository Repository, eventPublisher events.Publisher, moderator CommentModerator, editWindow time.Duration) *UpdateCommentHandler {
+	return &UpdateCommentHandler{
+		repository:     repository,
+		eventPublisher: eventPublisher,
+		moderator:      moderator,
+		editWindow:     editWindow,
+	}
+}
+
+type UpdateCommentRequest struct {
+	ItemID    string `params:"itemId" validate:"required,uuid"`
+	CommentID string `params:"commentId" validate:"required,uuid"`
+	Content   string `json:"content" validate:"required"`
+}
+
+type UpdateCommentResponse struct {
+	Comment domain.ItemComment `json:"comment"`
+}
+
+// Handle replaces the content of a comment of the current user. The previous
+// content is kept as a revision. Edits the moderator holds make the comment
+// pending until a moderator releases it.
+func (h *UpdateCommentHandler) Handle(ctx context.Context, req *UpdateCommentRequest) (*UpdateCommentResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"comment.update.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comment.update.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	comment, err := h.repository.GetCommentByID(ctx, req.CommentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, httperror.InternalServerError(
+			"comment.update.failed",
+			"Failed to get comment",
+			nil,
+		)
+	}
+	if err != nil || comment.ItemID != req.ItemID || comment.IsDeleted() {
+		return nil, httperror.NotFound(
+			"comment.update.not_found",
+			"Comment not found",
+			nil,
+		)
+	}
+
+	userID := ctx.Value("UserID").(string)
+
+	if comment.UserID != userID {
+		return nil, httperror.Forbidden(
+			"comment.update.forbidden",
+			"Only the author can edit a comment",
+			nil,
+		)
+	}
+
+	// Editing would bypass the moderation of hidden and held comments
+	if !comment.IsVisible() {
+		return nil, httperror.Conflict(
+			"comment.update.not_visible",
+			"Hidden or held comments cannot be edited",
+			map[string]any{"status": comment.Status},
+		)
+	}
+
+	if !comment.IsEditable(time.Now(), h.editWindow) {
+		return nil, httperror.Forbidden(
+			"comment.update.edit_window_closed",
+			"The comment can no longer be edited",
+			map[string]any{"editableUntil": comment.EditableUntil(h.editWindow)},
+		)
+	}
+
+	// Nothing to keep a revision of
+	if req.Content == comment.Content {
+		return &UpdateCommentResponse{
+			Comment: comment,
+		}, nil
+	}
+
+	edited := comment
+	edited.Content = req.Content
+	status, err := reviewComment(ctx, h.moderator, "comment.update", edited)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated domain.ItemComment
+	err = h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = h.repository.UpdateComment(ctx, comment.ID, req.Content, status, userID)
+		if err != nil {
+			return err
+		}
+
+		return h.publishEvent(ctx, comment, updated)
+	})
+	if err != nil {
+		// The comment was hidden, held or deleted since it was read
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperror.Conflict(
+				"comment.update.conflict",
+				"The comment was moderated or deleted meanwhile",
+				nil,
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"comment.update.failed",
+			"Failed to update comment",
+			nil,
+		)
+	}
+
+	return &UpdateCommentResponse{
+		Comment: updated,
+	}, nil
+}
+
+func (h UpdateCommentHandler) publishEvent(ctx context.Context, previous domain.ItemComment, comment domain.ItemComment) error {
+	eventPayload := events.ItemCommentUpdatedPayload{
+		ID:              comment.ID,
+		ItemID:          comment.ItemID,
+		AuthorID:        comment.UserID,
+		Content:         comment.Content,
+		PreviousContent: previous.Content,
+		Status:          string(comment.Status),
+		EditedAt:        *comment.EditedAt,
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemCommentUpdatedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.comment.updated event: %w", err)
+	}
+
+	return nil
+}
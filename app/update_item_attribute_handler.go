@@ -0,0 +1,105 @@
+package app
+
+import (
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
+	"context"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateItemAttributeHandler struct {
+	editor itemAttributesEditor
+}
+
+func NewUpdateItemAttributeHandler(repository Repository, eventPublisher events.Publisher) *UpdateItemAttributeHandler {
+	return &UpdateItemAttributeHandler{
+		editor: itemAttributesEditor{
+			repository:     repository,
+			eventPublisher: eventPublisher,
+		},
+	}
+}
+
+type UpdateItemAttributeRequest struct {
+	ItemID      string  `params:"itemId" validate:"required,uuid"`
+	AttributeID string  `params:"attributeId" validate:"required,uuid"`
+	Key         *string `json:"key" validate:"omitnil,min=1,max=100"`
+	Value       *string `json:"value" validate:"required_without=Key"`
+}
+
+type UpdateItemAttributeResponse struct {
+	Attribute domain.ItemAttribute `json:"attribute"`
+}
+
+// Handle changes the key or value of an attribute of one of the user's items.
+// Omitted fields keep their value.
+func (h UpdateItemAttributeHandler) Handle(ctx context.Context, req *UpdateItemAttributeRequest) (*UpdateItemAttributeResponse, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(req); err != nil {
+		if ve, ok := err.(validator.ValidationErrors); ok {
+			return nil, httperror.BadRequest(
+				"item.attributes.update.validation_failed",
+				"Validation failed for the request",
+				ve.Error(),
+			)
+		}
+
+		return nil, httperror.InternalServerError(
+			"item.attributes.update.validation_error",
+			"An unexpected validation error occurred",
+			nil,
+		)
+	}
+
+	attributes, _, err := h.editor.edit(ctx, "item.attributes.update", req.ItemID, func(schema domain.AttributeSchema, existing []domain.ItemAttribute) (domain.ItemAttributeChanges, error) {
+		i := slices.IndexFunc(existing, func(attribute domain.ItemAttribute) bool {
+			return attribute.ID == req.AttributeID
+		})
+		if i < 0 {
+			return domain.ItemAttributeChanges{}, errItemAttributeNotFound
+		}
+
+		current := existing[i]
+		updated := current
+		if req.Key != nil {
+			updated.Key = *req.Key
+		}
+		if req.Value != nil {
+			updated.Value = *req.Value
+		}
+
+		others := slices.Delete(slices.Clone(existing), i, i+1)
+		normalized, violations := schema.Validate([]domain.ItemAttribute{updated}, others, false)
+
+		// Renaming a required attribute would leave the item without it
+		previousKey := domain.NormalizeAttributeKey(current.Key)
+		if definition, ok := schema[previousKey]; ok && definition.Required && domain.NormalizeAttributeKey(updated.Key) != previousKey {
+			violations = append(violations, domain.AttributeViolation{Key: previousKey, Reason: domain.AttributeMissing, Type: definition.Type})
+		}
+
+		if len(violations) > 0 {
+			return domain.ItemAttributeChanges{}, &attributeViolationsError{violations: violations}
+		}
+
+		if normalized[0].Key == current.Key && normalized[0].Value == current.Value {
+			return domain.ItemAttributeChanges{}, nil
+		}
+
+		return domain.ItemAttributeChanges{Updated: normalized}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(attributes, func(attribute domain.ItemAttribute) bool {
+		return attribute.ID == req.AttributeID
+	})
+
+	return &UpdateItemAttributeResponse{
+		Attribute: attributes[i],
+	}, nil
+}
@@ -7,46 +7,53 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
-	"go.uber.org/zap"
 )
 
 type UpdateItemHandler struct {
 	repository     Repository
 	eventPublisher events.Publisher
+	languages      []string
 }
 
 type UpdateItemRequest struct {
-	ItemID                    string           `params:"id" validate:"required,uuid"`
-	Name                      *string          `json:"name,omitempty"`
-	Description               *string          `json:"description,omitempty"`
-	CurrencyCode              *string          `json:"currencyCode,omitempty" validate:"omitempty,iso4217"`
-	BidIncrement              *decimal.Decimal `json:"bidIncrement,omitempty"`
-	ReservePrice              *decimal.Decimal `json:"reservePrice,omitempty"`
-	BuyoutPrice               *decimal.Decimal `json:"buyoutPrice,omitempty"`
-	EndPrice                  *decimal.Decimal `json:"endPrice,omitempty"`
-	EndDate                   *time.Time       `json:"endDate,omitempty"`
-	Status                    *string          `json:"status,omitempty" validate:"omitempty,oneof=draft active sold cancelled"`
-	ExtensionThresholdMinutes *int             `json:"extensionThresholdMinutes,omitempty" db:"extension_threshold_minutes"`
-	ExtensionDurationMinutes  *int             `json:"extensionDurationMinutes,omitempty" db:"extension_duration_minutes"`
+	ItemID                    string             `params:"id" validate:"required,uuid"`
+	Name                      *string            `json:"name,omitempty"`
+	Description               *string            `json:"description,omitempty"`
+	CurrencyCode              *string            `json:"currencyCode,omitempty" validate:"omitempty,iso4217"`
+	Language                  *string            `json:"language,omitempty"`
+	StartPrice                *decimal.Decimal   `json:"startPrice,omitempty"`
+	BidIncrement              *decimal.Decimal   `json:"bidIncrement,omitempty"`
+	ReservePrice              *decimal.Decimal   `json:"reservePrice,omitempty"`
+	BuyoutPrice               *decimal.Decimal   `json:"buyoutPrice,omitempty"`
+	EndPrice                  *decimal.Decimal   `json:"endPrice,omitempty"`
+	StartDate                 *time.Time         `json:"startDate,omitempty"`
+	EndDate                   *time.Time         `json:"endDate,omitempty"`
+	Status                    *domain.ItemStatus `json:"status,omitempty" validate:"omitempty,oneof=draft scheduled active cancelled"`
+	ExtensionThresholdMinutes *int               `json:"extensionThresholdMinutes,omitempty" db:"extension_threshold_minutes"`
+	ExtensionDurationMinutes  *int               `json:"extensionDurationMinutes,omitempty" db:"extension_duration_minutes"`
 }
 
 type UpdateItemResponse struct {
 	Item domain.Item `json:"item"`
 }
 
-func NewUpdateItemHandler(repository Repository, eventPublisher events.Publisher) *UpdateItemHandler {
+// NewUpdateItemHandler creates the handler. languages are the text search
+// configurations items may be written in.
+func NewUpdateItemHandler(repository Repository, eventPublisher events.Publisher, languages []string) *UpdateItemHandler {
 	return &UpdateItemHandler{
 		repository:     repository,
 		eventPublisher: eventPublisher,
+		languages:      languages,
 	}
 }
 
 func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (*UpdateItemResponse, error) {
-	userID := ctx.Value("userID").(string)
+	userID := ctx.Value("UserID").(string)
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -66,7 +73,7 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 		)
 	}
 
-	item, err := e.repository.GetItem(ctx, req.ItemID)
+	item, err := e.repository.GetUserItem(ctx, req.ItemID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, httperror.NotFound(
@@ -83,6 +90,29 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 		)
 	}
 
+	if item.IsFinished() {
+		if req.Status != nil && *req.Status != item.Status {
+			return nil, statusConflict("item.update.illegal_transition", item.TransitionTo(*req.Status))
+		}
+
+		return nil, httperror.Conflict(
+			"item.update.finished",
+			"Finished items cannot be edited",
+			map[string]any{"status": item.Status},
+		)
+	}
+
+	wasLive := item.IsLive()
+	if wasLive {
+		if fields := req.lockedFields(); len(fields) > 0 {
+			return nil, httperror.Conflict(
+				"item.update.locked_fields",
+				"These fields cannot be changed once the auction is live",
+				map[string]any{"status": item.Status, "fields": fields},
+			)
+		}
+	}
+
 	if req.Name != nil {
 		item.Name = *req.Name
 	}
@@ -92,6 +122,20 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 	if req.CurrencyCode != nil {
 		item.CurrencyCode = *req.CurrencyCode
 	}
+	if req.Language != nil {
+		language, err := itemLanguage(req.Language, e.languages)
+		if err != nil {
+			return nil, httperror.BadRequest(
+				"item.update.unsupported_language",
+				"Unsupported item language",
+				map[string]any{"supportedLanguages": e.languages},
+			)
+		}
+		item.Language = language
+	}
+	if req.StartPrice != nil {
+		item.StartPrice = *req.StartPrice
+	}
 	if req.BidIncrement != nil {
 		item.BidIncrement = req.BidIncrement
 	}
@@ -104,12 +148,12 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 	if req.EndPrice != nil {
 		item.EndPrice = req.EndPrice
 	}
+	if req.StartDate != nil {
+		item.StartDate = *req.StartDate
+	}
 	if req.EndDate != nil {
 		item.EndDate = *req.EndDate
 	}
-	if req.Status != nil {
-		item.Status = *req.Status
-	}
 
 	if req.ExtensionThresholdMinutes != nil {
 		item.ExtensionThresholdMinutes = req.ExtensionThresholdMinutes
@@ -119,8 +163,57 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 		item.ExtensionDurationMinutes = req.ExtensionDurationMinutes
 	}
 
-	err = e.repository.UpdateUserItem(ctx, item, userID)
+	if !item.EndDate.After(item.StartDate) {
+		return nil, httperror.BadRequest(
+			"item.update.invalid_dates",
+			"End date must be after start date",
+			nil,
+		)
+	}
+
+	targetStatus := item.Status
+	if req.Status != nil {
+		targetStatus = *req.Status
+	}
+
+	// Items that are not live yet are (re)scheduled from their start date
+	if !wasLive && (targetStatus == domain.ItemStatusScheduled || targetStatus == domain.ItemStatusActive) {
+		now := time.Now().UTC()
+		if !item.EndDate.After(now) {
+			return nil, httperror.BadRequest(
+				"item.update.end_date_passed",
+				"End date must be in the future",
+				nil,
+			)
+		}
+
+		if err := item.Publish(now); err != nil {
+			return nil, statusConflict("item.update.illegal_transition", err)
+		}
+	} else if targetStatus != item.Status {
+		if err := item.TransitionTo(targetStatus); err != nil {
+			return nil, statusConflict("item.update.illegal_transition", err)
+		}
+	}
+
+	err = e.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := e.repository.UpdateUserItem(ctx, item, userID); err != nil {
+			return err
+		}
+
+		item.Version++
+
+		return e.publishEvent(ctx, item)
+	})
 	if err != nil {
+		if errors.Is(err, ErrOptimisticLock) {
+			return nil, httperror.Conflict(
+				"item.update.conflict",
+				"The item was modified by another request, please retry",
+				nil,
+			)
+		}
+
 		return nil, httperror.InternalServerError(
 			"item.update.update_failed",
 			"An error occurred while updating the item",
@@ -128,50 +221,87 @@ func (e UpdateItemHandler) Handle(ctx context.Context, req *UpdateItemRequest) (
 		)
 	}
 
-	e.publishEvent(ctx, item)
-
 	return &UpdateItemResponse{
 		Item: item,
 	}, nil
 }
 
-func (e UpdateItemHandler) publishEvent(ctx context.Context, item domain.Item) {
-	if e.eventPublisher != nil {
-		eventPayload := events.ItemUpdatedPayload{
-			ID:           item.ID,
-			Name:         item.Name,
-			Description:  item.Description,
-			CurrencyCode: item.CurrencyCode,
-			StartPrice:   item.StartPrice,
-			CurrentPrice: item.CurrentPrice,
-			BidIncrement: item.BidIncrement,
-			ReservePrice: item.ReservePrice,
-			BuyoutPrice:  item.BuyoutPrice,
-			EndPrice:     item.EndPrice,
-			StartDate:    item.StartDate,
-			EndDate:      item.EndDate,
-			Status:       item.Status,
-			UpdatedAt:    item.UpdatedAt,
-		}
+// lockedFields returns the requested changes that are not allowed once bidding is open
+func (req *UpdateItemRequest) lockedFields() []string {
+	fields := make([]string, 0)
 
-		headers := events.Headers{
-			TraceID:       events.GenerateTraceID(),
-			CorrelationID: events.GenerateCorrelationID(),
-			Service:       "auction",
-		}
+	if req.CurrencyCode != nil {
+		fields = append(fields, "currencyCode")
+	}
+	if req.StartPrice != nil {
+		fields = append(fields, "startPrice")
+	}
+	if req.BidIncrement != nil {
+		fields = append(fields, "bidIncrement")
+	}
+	if req.ReservePrice != nil {
+		fields = append(fields, "reservePrice")
+	}
+	if req.BuyoutPrice != nil {
+		fields = append(fields, "buyoutPrice")
+	}
+	if req.EndPrice != nil {
+		fields = append(fields, "endPrice")
+	}
+	if req.StartDate != nil {
+		fields = append(fields, "startDate")
+	}
+	if req.EndDate != nil {
+		fields = append(fields, "endDate")
+	}
+	if req.ExtensionThresholdMinutes != nil {
+		fields = append(fields, "extensionThresholdMinutes")
+	}
+	if req.ExtensionDurationMinutes != nil {
+		fields = append(fields, "extensionDurationMinutes")
+	}
 
-		event := events.NewEvent(
-			events.ItemUpdatedEvent,
-			events.EventVersionV1,
-			eventPayload,
-			headers,
-		)
+	return fields
+}
 
-		if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-			zap.L().Error("Failed to publish item.updated event",
-				zap.String("itemId", item.ID),
-				zap.Error(err),
-			)
-		}
+func (e UpdateItemHandler) publishEvent(ctx context.Context, item domain.Item) error {
+	if e.eventPublisher == nil {
+		return nil
+	}
+
+	eventPayload := events.ItemUpdatedPayload{
+		ID:           item.ID,
+		Name:         item.Name,
+		Description:  item.Description,
+		CurrencyCode: item.CurrencyCode,
+		StartPrice:   item.StartPrice,
+		CurrentPrice: item.CurrentPrice,
+		BidIncrement: item.BidIncrement,
+		ReservePrice: item.ReservePrice,
+		BuyoutPrice:  item.BuyoutPrice,
+		EndPrice:     item.EndPrice,
+		StartDate:    item.StartDate,
+		EndDate:      item.EndDate,
+		Status:       string(item.Status),
+		UpdatedAt:    item.UpdatedAt,
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
 	}
+
+	event := events.NewEvent(
+		events.ItemUpdatedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := e.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.updated event: %w", err)
+	}
+
+	return nil
 }
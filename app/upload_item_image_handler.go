@@ -1,29 +1,34 @@
 package app
 
 import (
+	"auction/domain"
 	"auction/pkg/aws"
-	"auction/pkg/config"
 	"auction/pkg/events"
 	"auction/pkg/httperror"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"time"
+	"mime/multipart"
+	"slices"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"go.uber.org/zap"
 )
 
 type UploadItemImageHandler struct {
 	repository     Repository
 	eventPublisher events.Publisher
+	maxImages      int
 }
 
-func NewUploadItemImageHandler(repository Repository, eventPublisher events.Publisher) *UploadItemImageHandler {
+// NewUploadItemImageHandler creates the handler. Items hold at most maxImages
+// images; 0 means no limit.
+func NewUploadItemImageHandler(repository Repository, eventPublisher events.Publisher, maxImages int) *UploadItemImageHandler {
 	return &UploadItemImageHandler{
 		repository:     repository,
 		eventPublisher: eventPublisher,
+		maxImages:      maxImages,
 	}
 }
 
@@ -32,11 +37,20 @@ type UploadItemImageRequest struct {
 }
 
 type UploadItemImageResponse struct {
-	ItemID   string `json:"item_id"`
-	ImageID  string `json:"image_id"`
-	ImageUrl string `json:"image_url"`
+	ItemID   string             `json:"item_id"`
+	ImageID  string             `json:"image_id"` // The first uploaded image
+	ImageUrl string             `json:"image_url"`
+	Images   []domain.ItemImage `json:"images"` // Every uploaded image in display order
 }
 
+// imageUpload is a validated image file of the request
+type imageUpload struct {
+	data        []byte
+	contentType string
+}
+
+// Handle stores the images of the request after the existing images of the
+// item. Either all of them are stored or none.
 func (h *UploadItemImageHandler) Handle(ctx context.Context, req *UploadItemImageRequest) (*UploadItemImageResponse, error) {
 	fiberCtx := ctx.Value("fiber")
 	if fiberCtx == nil {
@@ -58,16 +72,45 @@ func (h *UploadItemImageHandler) Handle(ctx context.Context, req *UploadItemImag
 		return nil, httperror.Forbidden("upload_item_image.forbidden", "You are not authorized to upload images for this item.", nil)
 	}
 
-	file, err := c.FormFile("image")
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, httperror.BadRequest("upload.missing_file", "Image file is required (use 'image' or 'images' field)", fiber.Map{"error": err.Error()})
+	}
+
+	// "image" takes a single file, "images" any number of them
+	files := slices.Concat(form.File["image"], form.File["images"])
+	if len(files) == 0 {
+		return nil, httperror.BadRequest("upload.missing_file", "Image file is required (use 'image' or 'images' field)", nil)
+	}
+
+	// Fail before reading any file; the limit is checked again when saving
+	count, err := itemImageSlots(ctx, h.repository, req.ItemID)
 	if err != nil {
-		return nil, httperror.BadRequest("upload.missing_file", "Image file is required (use 'image' field)", fiber.Map{"error": err.Error()})
+		return nil, httperror.InternalServerError("upload_item.count.failed", "Failed to count item images", err.Error())
 	}
+	if err := checkImageLimit("upload.too_many_images", h.maxImages, count, len(files)); err != nil {
+		return nil, err
+	}
+
+	uploads := make([]imageUpload, len(files))
+	for i, file := range files {
+		upload, err := readImageFile(file)
+		if err != nil {
+			return nil, err
+		}
+		uploads[i] = upload
+	}
+
+	return h.processUpload(ctx, req.ItemID, uploads)
+}
 
+func readImageFile(file *multipart.FileHeader) (imageUpload, error) {
 	// Validate file size (max 5MB)
 	const maxFileSize = 5 * 1024 * 1024
 	if file.Size > maxFileSize {
-		return nil, httperror.BadRequest("upload.file_too_large", "File size must not exceed 5MB",
+		return imageUpload{}, httperror.BadRequest("upload.file_too_large", "File size must not exceed 5MB",
 			fiber.Map{
+				"file":    file.Filename,
 				"size_mb": float64(file.Size) / 1024 / 1024,
 				"max_mb":  5,
 			})
@@ -83,8 +126,9 @@ func (h *UploadItemImageHandler) Handle(ctx context.Context, req *UploadItemImag
 		"image/jpg":  true,
 	}
 	if !allowedTypes[contentType] {
-		return nil, httperror.BadRequest("upload.invalid_content_type", "Only PNG, JPEG/JPG images are allowed",
+		return imageUpload{}, httperror.BadRequest("upload.invalid_content_type", "Only PNG, JPEG/JPG images are allowed",
 			fiber.Map{
+				"file":     file.Filename,
 				"received": contentType,
 				"allowed":  []string{"image/png", "image/jpeg", "image/jpg"},
 			})
@@ -92,74 +136,130 @@ func (h *UploadItemImageHandler) Handle(ctx context.Context, req *UploadItemImag
 
 	fileReader, err := file.Open()
 	if err != nil {
-		return nil, httperror.InternalServerError("upload.file_open_error", "Failed to open uploaded file", err.Error())
+		return imageUpload{}, httperror.InternalServerError("upload.file_open_error", "Failed to open uploaded file", err.Error())
 	}
 	defer fileReader.Close()
 
 	fileBytes, err := io.ReadAll(fileReader)
 	if err != nil {
-		return nil, httperror.InternalServerError("upload.file_read_error", "Failed to read file content", err.Error())
+		return imageUpload{}, httperror.InternalServerError("upload.file_read_error", "Failed to read file content", err.Error())
 	}
 
-	return h.processUpload(ctx, req.ItemID, fileBytes, contentType, file.Filename)
+	return imageUpload{
+		data:        fileBytes,
+		contentType: contentType,
+	}, nil
 }
 
-func (h *UploadItemImageHandler) processUpload(ctx context.Context, itemID string, imageData []byte, contentType, fileName string) (*UploadItemImageResponse, error) {
-	extension := getExtensionFromContentType(contentType)
-
-	key := fmt.Sprintf("items/%s/%s%s", itemID, uuid.New().String(), extension)
-
+func (h *UploadItemImageHandler) processUpload(ctx context.Context, itemID string, uploads []imageUpload) (*UploadItemImageResponse, error) {
 	bucket := aws.NewS3Bucket()
 
-	err := bucket.Upload(key, imageData)
-	if err != nil {
-		return nil, httperror.InternalServerError("upload_item.upload.failed", "Failed to upload image to storage", err.Error())
+	keys := make([]string, 0, len(uploads))
+	// Removes the stored files when the upload fails midway
+	deleteUploaded := func() {
+		for _, key := range keys {
+			_ = bucket.Delete(key)
+		}
 	}
 
-	imageURL := constructImageURL(key)
+	for _, upload := range uploads {
+		extension := getExtensionFromContentType(upload.contentType)
 
-	savedImage, err := h.repository.SaveImage(ctx, itemID, imageURL)
-	if err != nil {
-		_ = bucket.Delete(key)
-		return nil, httperror.InternalServerError("upload_item.store.failed", "Failed to save image metadata", err.Error())
+		key := fmt.Sprintf("items/%s/%s%s", itemID, uuid.New().String(), extension)
+
+		err := bucket.Upload(key, upload.data)
+		if err != nil {
+			deleteUploaded()
+			return nil, httperror.InternalServerError("upload_item.upload.failed", "Failed to upload image to storage", err.Error())
+		}
+
+		keys = append(keys, key)
 	}
 
-	if h.eventPublisher != nil {
-		eventPayload := events.ItemImageUploadedPayload{
-			ID:        savedImage.ID,
-			ItemID:    itemID,
-			ImageURL:  imageURL,
-			CreatedAt: time.Now(),
+	savedImages := make([]domain.ItemImage, 0, len(keys))
+	err := h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockItemImages(ctx, itemID); err != nil {
+			return err
+		}
+
+		count, err := itemImageSlots(ctx, h.repository, itemID)
+		if err != nil {
+			return err
 		}
+		if err := checkImageLimit("upload.too_many_images", h.maxImages, count, len(keys)); err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			savedImage, err := h.repository.SaveImage(ctx, itemID, aws.ObjectURL(key))
+			if err != nil {
+				return err
+			}
 
-		headers := events.Headers{
-			TraceID:       events.GenerateTraceID(),
-			CorrelationID: events.GenerateCorrelationID(),
-			Service:       "auction",
+			if err := publishImageUploaded(ctx, h.eventPublisher, savedImage); err != nil {
+				return err
+			}
+
+			savedImages = append(savedImages, savedImage)
 		}
 
-		event := events.NewEvent(
-			events.ItemImageUploadedEvent,
-			events.EventVersionV1,
-			eventPayload,
-			headers,
-		)
-
-		if err := h.eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
-			zap.L().Error("Failed to publish item.image.uploaded event",
-				zap.String("imageID", savedImage.ID),
-				zap.Error(err),
-			)
+		return nil
+	})
+	if err != nil {
+		deleteUploaded()
+
+		var httpErr *httperror.Error
+		if errors.As(err, &httpErr) {
+			return nil, httpErr
 		}
+
+		return nil, httperror.InternalServerError("upload_item.store.failed", "Failed to save image metadata", err.Error())
 	}
 
 	return &UploadItemImageResponse{
 		ItemID:   itemID,
-		ImageID:  savedImage.ID,
-		ImageUrl: savedImage.ImageURL,
+		ImageID:  savedImages[0].ID,
+		ImageUrl: savedImages[0].ImageURL,
+		Images:   savedImages,
 	}, nil
 }
 
+// publishImageUploaded announces an image stored by a multipart or a
+// presigned upload
+func publishImageUploaded(ctx context.Context, eventPublisher events.Publisher, image domain.ItemImage) error {
+	if eventPublisher == nil {
+		return nil
+	}
+
+	eventPayload := events.ItemImageUploadedPayload{
+		ID:           image.ID,
+		ItemID:       image.ItemID,
+		ImageURL:     image.ImageURL,
+		DisplayOrder: image.DisplayOrder,
+		IsPrimary:    image.IsPrimary,
+		CreatedAt:    image.CreatedAt,
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		events.ItemImageUploadedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := eventPublisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish item.image.uploaded event: %w", err)
+	}
+
+	return nil
+}
+
 func getExtensionFromContentType(contentType string) string {
 	switch contentType {
 	case "image/svg+xml":
@@ -174,22 +274,3 @@ func getExtensionFromContentType(contentType string) string {
 		return ".jpg"
 	}
 }
-
-func constructImageURL(key string) string {
-	// Get AWS config
-	cfg := config.Read()
-
-	// For MinIO/S3, construct the public URL
-	// Format: http(s)://endpoint/bucket/key
-	if cfg.AWSEndpoint != "" {
-		return fmt.Sprintf("%s/%s/%s", cfg.AWSEndpoint, cfg.AWSBucket, key)
-	}
-
-	// For AWS S3, use standard URL format
-	if cfg.AWSDefaultRegion != "" {
-		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", cfg.AWSBucket, cfg.AWSDefaultRegion, key)
-	}
-
-	// Fallback
-	return key
-}
@@ -2,8 +2,8 @@ package main
 
 import (
 	auctionApp "auction/app"
+	"auction/infra/moderation"
 	"auction/infra/postgres"
-	"auction/infra/rabbitmq"
 	"auction/internal/middleware"
 	"auction/pkg/config"
 	"auction/pkg/httperror"
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -96,62 +97,118 @@ func main() {
 		appConfig.PostgresPort,
 	)
 
-	// Initialize RabbitMQ publisher
-	var eventPublisher *rabbitmq.RabbitMQPublisher
-	var err error
-	if appConfig.RabbitMQURL != "" {
-		eventPublisher, err = rabbitmq.NewRabbitMQPublisher(
-			appConfig.RabbitMQURL,
-			appConfig.ServiceName,
-		)
-		if err != nil {
-			zap.L().Error("Failed to initialize RabbitMQ publisher", zap.Error(err))
-		} else {
-			defer eventPublisher.Close()
-		}
-	}
+	// Events are written to the outbox in the same transaction as the change
+	// that produced them; the worker relays them to RabbitMQ
+	eventPublisher := postgres.NewOutboxPublisher(pgRepository)
 
-	createItemHadler := auctionApp.NewCreateItemHandler(pgRepository, eventPublisher)
+	languages := searchLanguages(appConfig.SearchLanguages)
+	createItemHadler := auctionApp.NewCreateItemHandler(pgRepository, eventPublisher, languages)
 	getItemsHandler := auctionApp.NewGetItemsHandler(pgRepository)
 	getItemHandler := auctionApp.NewGetItemHandler(pgRepository)
+	getItemFacetsHandler := auctionApp.NewGetItemFacetsHandler(pgRepository)
+	searchItemsHandler := auctionApp.NewSearchItemsHandler(pgRepository, languages)
+	getOwnerItemHandler := auctionApp.NewGetOwnerItemHandler(pgRepository)
 	deleteItemHandler := auctionApp.NewDeleteItemHandler(pgRepository, eventPublisher)
-	updateItemHandler := auctionApp.NewUpdateItemHandler(pgRepository, eventPublisher)
+	updateItemHandler := auctionApp.NewUpdateItemHandler(pgRepository, eventPublisher, languages)
 	getCategoriesHandler := auctionApp.NewGetCategoriesHandler(pgRepository)
 	getCategoryHandler := auctionApp.NewGetCategoryHandler(pgRepository)
+	getCategoryTreeHandler := auctionApp.NewGetCategoryTreeHandler(pgRepository)
+	getCategoryBreadcrumbsHandler := auctionApp.NewGetCategoryBreadcrumbsHandler(pgRepository)
+	createCategoryHandler := auctionApp.NewCreateCategoryHandler(pgRepository)
+	updateCategoryHandler := auctionApp.NewUpdateCategoryHandler(pgRepository)
+	moveCategoryHandler := auctionApp.NewMoveCategoryHandler(pgRepository)
+	archiveCategoryHandler := auctionApp.NewArchiveCategoryHandler(pgRepository)
+	deleteCategoryHandler := auctionApp.NewDeleteCategoryHandler(pgRepository)
+	getCategoryAttributesHandler := auctionApp.NewGetCategoryAttributesHandler(pgRepository)
+	replaceCategoryAttributesHandler := auctionApp.NewReplaceCategoryAttributesHandler(pgRepository)
 	getCommentsHandler := auctionApp.NewGetCommentsHandler(pgRepository)
-	createCommentHandler := auctionApp.NewCreateCommentHandler(pgRepository, eventPublisher)
+	getCommentThreadsHandler := auctionApp.NewGetCommentThreadsHandler(pgRepository)
+	getCommentRepliesHandler := auctionApp.NewGetCommentRepliesHandler(pgRepository)
+	commentModerator := moderation.NewWordListModerator(settingList(appConfig.CommentHeldWords))
+	createCommentHandler := auctionApp.NewCreateCommentHandler(pgRepository, eventPublisher, commentModerator)
+	updateCommentHandler := auctionApp.NewUpdateCommentHandler(pgRepository, eventPublisher, commentModerator, appConfig.CommentEditWindow)
 	deleteCommentHandler := auctionApp.NewDeleteCommentHandler(pgRepository, eventPublisher)
+	reportCommentHandler := auctionApp.NewReportCommentHandler(pgRepository, eventPublisher, appConfig.CommentReportThreshold)
+	hideCommentHandler := auctionApp.NewHideCommentHandler(pgRepository, eventPublisher)
+	unhideCommentHandler := auctionApp.NewUnhideCommentHandler(pgRepository, eventPublisher)
+	getModerationCommentsHandler := auctionApp.NewGetModerationCommentsHandler(pgRepository)
+	getSellerQuestionsHandler := auctionApp.NewGetSellerQuestionsHandler(pgRepository)
 	getItemImagesHandler := auctionApp.NewGetItemImagesHandler(pgRepository)
-	uploadItemImageHandler := auctionApp.NewUploadItemImageHandler(pgRepository, eventPublisher)
+	uploadItemImageHandler := auctionApp.NewUploadItemImageHandler(pgRepository, eventPublisher, appConfig.ItemMaxImages)
 	deleteItemImageHandler := auctionApp.NewDeleteItemImageHandler(pgRepository, eventPublisher)
+	createImageUploadHandler := auctionApp.NewCreateImageUploadHandler(pgRepository, appConfig.ItemMaxImages, appConfig.ImageUploadURLTTL, appConfig.ImageUploadMaxBytes)
+	completeImageUploadHandler := auctionApp.NewCompleteImageUploadHandler(pgRepository, eventPublisher, appConfig.ItemMaxImages, appConfig.ImageUploadMaxBytes)
+	reorderItemImagesHandler := auctionApp.NewReorderItemImagesHandler(pgRepository, eventPublisher)
+	setPrimaryItemImageHandler := auctionApp.NewSetPrimaryItemImageHandler(pgRepository, eventPublisher)
 	createItemAttributesHandler := auctionApp.NewCreateItemAttributesHandler(pgRepository, eventPublisher)
 	getItemAttributesHandler := auctionApp.NewGetItemAttributesHandler(pgRepository)
 	getItemAttributeHandler := auctionApp.NewGetItemAttributeHandler(pgRepository)
 	deleteItemAttributeHandler := auctionApp.NewDeleteItemAttributeHandler(pgRepository, eventPublisher)
+	updateItemAttributeHandler := auctionApp.NewUpdateItemAttributeHandler(pgRepository, eventPublisher)
+	replaceItemAttributesHandler := auctionApp.NewReplaceItemAttributesHandler(pgRepository, eventPublisher)
+	buyoutItemHandler := auctionApp.NewBuyoutItemHandler(pgRepository, eventPublisher)
+	addItemCategoriesHandler := auctionApp.NewAddItemCategoriesHandler(pgRepository, eventPublisher)
+	removeItemCategoriesHandler := auctionApp.NewRemoveItemCategoriesHandler(pgRepository, eventPublisher)
+	replaceItemCategoriesHandler := auctionApp.NewReplaceItemCategoriesHandler(pgRepository, eventPublisher)
 
 	securityHeadersHandler := middleware.NewSecurityHeadersMiddleware()
+	optionalSecurityHeadersHandler := middleware.NewOptionalSecurityHeadersMiddleware()
+	requireAdminHandler := middleware.NewRequireRoleMiddleware(auctionApp.RoleAdmin)
+	requireModeratorHandler := middleware.NewRequireRoleMiddleware(auctionApp.RoleModerator)
 
 	publicRoutes := app.Group("/api/v1")
-	publicRoutes.Get("/items", handle[auctionApp.GetItemsRequest, auctionApp.GetItemsResponse](getItemsHandler))
+	publicRoutes.Get("/items", optionalSecurityHeadersHandler, handle[auctionApp.GetItemsRequest, auctionApp.GetItemsResponse](getItemsHandler))
+	publicRoutes.Get("/items/facets", optionalSecurityHeadersHandler, handle[auctionApp.GetItemFacetsRequest, auctionApp.GetItemFacetsResponse](getItemFacetsHandler)) // Must be registered before /items/:id
+	publicRoutes.Get("/items/search", handle[auctionApp.SearchItemsRequest, auctionApp.SearchItemsResponse](searchItemsHandler))                                       // Must be registered before /items/:id
 	publicRoutes.Get("/items/:id", handle[auctionApp.GetItemRequest, auctionApp.GetItemResponse](getItemHandler))
 	publicRoutes.Get("/items/:id/comments", handle[auctionApp.GetCommentsRequest, auctionApp.GetCommentsResponse](getCommentsHandler))
+	publicRoutes.Get("/items/:id/comments/threads", handle[auctionApp.GetCommentThreadsRequest, auctionApp.GetCommentThreadsResponse](getCommentThreadsHandler))
+	publicRoutes.Get("/items/:itemId/comments/:commentId/replies", handle[auctionApp.GetCommentRepliesRequest, auctionApp.GetCommentRepliesResponse](getCommentRepliesHandler))
 	publicRoutes.Get("/categories", handle[auctionApp.GetCategoriesRequest, auctionApp.GetCategoriesResponse](getCategoriesHandler))
+	publicRoutes.Get("/categories/tree", handle[auctionApp.GetCategoryTreeRequest, auctionApp.GetCategoryTreeResponse](getCategoryTreeHandler)) // Must be registered before /categories/:id
 	publicRoutes.Get("/categories/:id", handle[auctionApp.GetCategoryRequest, auctionApp.GetCategoryResponse](getCategoryHandler))
+	publicRoutes.Get("/categories/:id/breadcrumbs", handle[auctionApp.GetCategoryBreadcrumbsRequest, auctionApp.GetCategoryBreadcrumbsResponse](getCategoryBreadcrumbsHandler))
+	publicRoutes.Get("/categories/:id/attributes", handle[auctionApp.GetCategoryAttributesRequest, auctionApp.GetCategoryAttributesResponse](getCategoryAttributesHandler))
 	publicRoutes.Get("/items/:id/images", handle[auctionApp.GetItemImagesRequest, auctionApp.GetItemImagesResponse](getItemImagesHandler))
 	publicRoutes.Get("/items/:itemId/attributes", handle[auctionApp.GetItemAttributesRequest, auctionApp.GetItemAttributesResponse](getItemAttributesHandler))
 	publicRoutes.Get("/items/:itemId/attributes/:attributeId", handle[auctionApp.GetItemAttributeRequest, auctionApp.GetItemAttributeResponse](getItemAttributeHandler))
 
 	privateRoutes := app.Group("/api/v1", securityHeadersHandler)
+	privateRoutes.Get("/items/:id/owner", handle[auctionApp.GetOwnerItemRequest, auctionApp.GetOwnerItemResponse](getOwnerItemHandler))
 	privateRoutes.Post("/items", handle[auctionApp.CreateItemRequest, auctionApp.CreateItemResponse](createItemHadler))
 	privateRoutes.Put("/items/:id", handle[auctionApp.UpdateItemRequest, auctionApp.UpdateItemResponse](updateItemHandler))
 	privateRoutes.Delete("/items/:id", handle[auctionApp.DeleteItemRequest, auctionApp.DeleteItemResponse](deleteItemHandler))
+	privateRoutes.Post("/items/:id/buyout", handle[auctionApp.BuyoutItemRequest, auctionApp.BuyoutItemResponse](buyoutItemHandler))
+	privateRoutes.Post("/items/:id/categories", handle[auctionApp.AddItemCategoriesRequest, auctionApp.AddItemCategoriesResponse](addItemCategoriesHandler))
+	privateRoutes.Put("/items/:id/categories", handle[auctionApp.ReplaceItemCategoriesRequest, auctionApp.ReplaceItemCategoriesResponse](replaceItemCategoriesHandler))
+	privateRoutes.Delete("/items/:id/categories", handle[auctionApp.RemoveItemCategoriesRequest, auctionApp.RemoveItemCategoriesResponse](removeItemCategoriesHandler))
 	privateRoutes.Post("/items/:id/comments", handle[auctionApp.CreateCommentRequest, auctionApp.CreateCommentResponse](createCommentHandler))
+	privateRoutes.Patch("/items/:itemId/comments/:commentId", handle[auctionApp.UpdateCommentRequest, auctionApp.UpdateCommentResponse](updateCommentHandler))
 	privateRoutes.Delete("/items/:itemId/comments/:commentId", handle[auctionApp.DeleteCommentRequest, auctionApp.DeleteCommentResponse](deleteCommentHandler))
+	privateRoutes.Post("/items/:itemId/comments/:commentId/report", handle[auctionApp.ReportCommentRequest, auctionApp.ReportCommentResponse](reportCommentHandler))
+	privateRoutes.Post("/items/:itemId/comments/:commentId/hide", handle[auctionApp.HideCommentRequest, auctionApp.HideCommentResponse](hideCommentHandler))
+	privateRoutes.Post("/items/:itemId/comments/:commentId/unhide", handle[auctionApp.UnhideCommentRequest, auctionApp.UnhideCommentResponse](unhideCommentHandler))
+	privateRoutes.Get("/seller/questions", handle[auctionApp.GetSellerQuestionsRequest, auctionApp.GetSellerQuestionsResponse](getSellerQuestionsHandler))
+	privateRoutes.Get("/moderation/comments", requireModeratorHandler, handle[auctionApp.GetModerationCommentsRequest, auctionApp.GetModerationCommentsResponse](getModerationCommentsHandler))
 	privateRoutes.Post("/items/:itemId/images", handle[auctionApp.UploadItemImageRequest, auctionApp.UploadItemImageResponse](uploadItemImageHandler))
+	privateRoutes.Post("/items/:itemId/images/upload-url", handle[auctionApp.CreateImageUploadRequest, auctionApp.CreateImageUploadResponse](createImageUploadHandler))
+	privateRoutes.Post("/items/:itemId/images/:imageId/complete", handle[auctionApp.CompleteImageUploadRequest, auctionApp.CompleteImageUploadResponse](completeImageUploadHandler))
+	privateRoutes.Put("/items/:itemId/images/order", handle[auctionApp.ReorderItemImagesRequest, auctionApp.ReorderItemImagesResponse](reorderItemImagesHandler))
+	privateRoutes.Put("/items/:itemId/images/:imageId/primary", handle[auctionApp.SetPrimaryItemImageRequest, auctionApp.SetPrimaryItemImageResponse](setPrimaryItemImageHandler))
 	privateRoutes.Delete("/items/:itemId/images/:imageId", handle[auctionApp.DeleteItemImageRequest, auctionApp.DeleteItemImageResponse](deleteItemImageHandler))
 	privateRoutes.Post("/items/:itemId/attributes", handle[auctionApp.CreateItemAttributesRequest, auctionApp.CreateItemAttributesResponse](createItemAttributesHandler))
+	privateRoutes.Put("/items/:itemId/attributes", handle[auctionApp.ReplaceItemAttributesRequest, auctionApp.ReplaceItemAttributesResponse](replaceItemAttributesHandler))
+	privateRoutes.Patch("/items/:itemId/attributes/:attributeId", handle[auctionApp.UpdateItemAttributeRequest, auctionApp.UpdateItemAttributeResponse](updateItemAttributeHandler))
 	privateRoutes.Delete("/items/:itemId/attributes/:attributeId", handle[auctionApp.DeleteItemAttributeRequest, auctionApp.DeleteItemAttributeResponse](deleteItemAttributeHandler))
 
+	// Category management requires the admin role
+	privateRoutes.Post("/categories", requireAdminHandler, handle[auctionApp.CreateCategoryRequest, auctionApp.CreateCategoryResponse](createCategoryHandler))
+	privateRoutes.Put("/categories/:id", requireAdminHandler, handle[auctionApp.UpdateCategoryRequest, auctionApp.UpdateCategoryResponse](updateCategoryHandler))
+	privateRoutes.Put("/categories/:id/parent", requireAdminHandler, handle[auctionApp.MoveCategoryRequest, auctionApp.MoveCategoryResponse](moveCategoryHandler))
+	privateRoutes.Post("/categories/:id/archive", requireAdminHandler, handle[auctionApp.ArchiveCategoryRequest, auctionApp.ArchiveCategoryResponse](archiveCategoryHandler))
+	privateRoutes.Put("/categories/:id/attributes", requireAdminHandler, handle[auctionApp.ReplaceCategoryAttributesRequest, auctionApp.ReplaceCategoryAttributesResponse](replaceCategoryAttributesHandler))
+	privateRoutes.Delete("/categories/:id", requireAdminHandler, handle[auctionApp.DeleteCategoryRequest, auctionApp.DeleteCategoryResponse](deleteCategoryHandler))
+
 	// Start server in a goroutine
 	go func() {
 		if err := app.Listen(fmt.Sprintf("0.0.0.0:%s", appConfig.Port)); err != nil {
@@ -218,3 +275,26 @@ func writeError(c *fiber.Ctx, err error) error {
 		"message": "Internal server error.",
 	})
 }
+
+// searchLanguages parses the comma separated SEARCH_LANGUAGES setting, e.g. "english,simple"
+func searchLanguages(setting string) []string {
+	languages := settingList(setting)
+
+	if len(languages) == 0 {
+		return []string{"english"}
+	}
+
+	return languages
+}
+
+// settingList parses a comma separated setting into its lowercase, non-empty entries
+func settingList(setting string) []string {
+	entries := make([]string, 0)
+	for _, entry := range strings.Split(setting, ",") {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
@@ -40,7 +40,10 @@ func main() {
 		appConfig.PostgresPort,
 	)
 
-	itemService := grpc.NewItemServiceServer(pgRepository)
+	// Events are written to the outbox and relayed to RabbitMQ by the worker
+	eventPublisher := postgres.NewOutboxPublisher(pgRepository)
+
+	itemService := grpc.NewItemServiceServer(pgRepository, eventPublisher)
 	itemv1.RegisterItemServiceServer(grpcServer.GetGRPCServer(), itemService)
 
 	zap.L().Info("starting gRPC server...", zap.String("port", appConfig.GRPCPort))
@@ -4,7 +4,11 @@ import (
 	"auction/infra/postgres"
 	"auction/infra/rabbitmq"
 	"auction/internal/consumers"
+	"auction/internal/scheduler"
+	"auction/pkg/aws"
 	"auction/pkg/config"
+	"auction/pkg/events"
+	"auction/pkg/imageproc"
 	"context"
 	"os"
 	"os/signal"
@@ -44,16 +48,21 @@ func main() {
 		appConfig.PostgresPort,
 	)
 
+	// Events emitted by the worker are written to the outbox within the transaction
+	// that produced them and relayed to RabbitMQ by the outbox relay job
+	outboxPublisher := postgres.NewOutboxPublisher(pgRepository)
+
 	// Initialize bid event handler
 	bidHandler := consumers.NewBidEventHandler(
 		pgRepository,
+		outboxPublisher,
 		zap.L(),
 	)
 
 	// Configure bid consumer
 	// This consumes events from the "bid" service
 	bidConsumerConfig := rabbitmq.ConsumerConfig{
-		Exchange:       "bid.bidding",         // Exchange where bid service publishes
+		Exchange:       events.BidExchange,    // Exchange where bid service publishes
 		QueueName:      "bid.bidding.all.v1",  // Queue name: {service}.{domain}.{events}.{version}
 		RoutingKeys:    []string{"bid.#"},     // Consume all bid events (placed, cancelled, won)
 		ServiceName:    appConfig.ServiceName, // "auction"
@@ -67,6 +76,68 @@ func main() {
 	}
 	defer bidConsumer.Close()
 
+	// Initialize image event handler
+	renditions, err := imageproc.ParseRenditions(appConfig.ImageRenditions)
+	if err != nil {
+		zap.L().Fatal("Invalid IMAGE_RENDITIONS", zap.Error(err))
+	}
+	formats, err := imageproc.ParseFormats(appConfig.ImageRenditionFormats)
+	if err != nil {
+		zap.L().Fatal("Invalid IMAGE_RENDITION_FORMATS", zap.Error(err))
+	}
+
+	imageHandler := consumers.NewImageEventHandler(
+		pgRepository,
+		aws.NewS3Bucket(),
+		imageproc.NewProcessor(renditions, formats, appConfig.ImageQuality),
+		outboxPublisher,
+		zap.L(),
+	)
+
+	// Configure image consumer
+	// This consumes the images uploaded to items of this service
+	imageConsumerConfig := rabbitmq.ConsumerConfig{
+		Exchange:       events.ItemExchange,                                                   // Exchange where this service publishes
+		QueueName:      "auction.item.image.processing.v1",                                    // Queue name: {service}.{domain}.{events}.{version}
+		RoutingKeys:    []string{events.ItemImageUploadedEvent + "." + events.EventVersionV1}, // Only uploaded images are processed
+		ServiceName:    appConfig.ServiceName,                                                 // "auction"
+		PrefetchCount:  4,                                                                     // Images are large, prefetch few messages
+		WorkerPoolSize: 4,                                                                     // Decoding and encoding is CPU bound
+	}
+
+	imageConsumer, err := rabbitmq.NewConsumer(appConfig.RabbitMQURL, imageConsumerConfig)
+	if err != nil {
+		zap.L().Fatal("Failed to create image consumer", zap.Error(err))
+	}
+	defer imageConsumer.Close()
+
+	// Initialize RabbitMQ publisher used by the outbox relay
+	rabbitPublisher, err := rabbitmq.NewRabbitMQPublisher(appConfig.RabbitMQURL, appConfig.ServiceName)
+	if err != nil {
+		zap.L().Fatal("Failed to create event publisher", zap.Error(err))
+	}
+	defer rabbitPublisher.Close()
+
+	// Configure scheduled jobs
+	// Every replica runs the scheduler; jobs claim rows with SKIP LOCKED so work is not duplicated
+	jobScheduler := scheduler.New()
+	jobScheduler.Register(
+		scheduler.NewAuctionStarter(pgRepository, outboxPublisher, 100), // Activate up to 100 items per transaction
+		15*time.Second, // Poll for scheduled auctions every 15 seconds
+	)
+	jobScheduler.Register(
+		scheduler.NewAuctionCloser(pgRepository, outboxPublisher, 100), // Close up to 100 items per transaction
+		15*time.Second, // Poll for expired auctions every 15 seconds
+	)
+	jobScheduler.Register(
+		scheduler.NewImageUploadSweeper(pgRepository, aws.NewS3Bucket(), 100, time.Hour), // Sweep uploads expired for an hour
+		5*time.Minute, // Look for stale uploads every 5 minutes
+	)
+	jobScheduler.Register(
+		scheduler.NewOutboxRelay(pgRepository, rabbitPublisher, 100, 10, 7*24*time.Hour), // Park messages failing 10 times, keep published ones for a week
+		time.Second, // Relay pending events every second
+	)
+
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -85,6 +156,20 @@ func main() {
 		}
 	}()
 
+	// Start image consumer in goroutine
+	go func() {
+		zap.L().Info("Starting image event consumer...")
+		if err := imageConsumer.Consume(ctx, imageHandler.HandleEvent); err != nil {
+			if err != context.Canceled {
+				zap.L().Error("Image consumer error", zap.Error(err))
+			}
+		}
+	}()
+
+	// Start scheduled jobs
+	zap.L().Info("Starting scheduler...")
+	jobScheduler.Start(ctx)
+
 	// Start connection pool monitoring
 	go func() {
 		ticker := time.NewTicker(30 * time.Second)
@@ -110,7 +195,8 @@ func main() {
 
 	zap.L().Info("Worker service started successfully. Waiting for events...")
 	zap.L().Info("Consuming from exchanges",
-		zap.String("bidExchange", "bid.bidding"),
+		zap.String("bidExchange", events.BidExchange),
+		zap.String("itemExchange", events.ItemExchange),
 	)
 	zap.L().Info("Press Ctrl+C to stop...")
 
@@ -118,6 +204,7 @@ func main() {
 	<-sigChan
 	zap.L().Info("Shutdown signal received, stopping worker service...")
 	cancel()
+	jobScheduler.Wait()
 
 	zap.L().Info("Worker service stopped gracefully")
 }
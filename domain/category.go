@@ -1,6 +1,20 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Category statuses
+const (
+	CategoryStatusActive   = "active"
+	CategoryStatusInactive = "inactive"
+	CategoryStatusArchived = "archived"
+)
+
+// ErrCategoryCycle is returned when a move would make a category its own ancestor
+var ErrCategoryCycle = errors.New("category cannot be moved under itself or its descendants")
 
 type Category struct {
 	ID          string    `json:"id" db:"id"`
@@ -11,3 +25,28 @@ type Category struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
+
+func (c *Category) IsArchived() bool {
+	return c.Status == CategoryStatusArchived
+}
+
+// MoveTo re-parents the category. parentPath is the path from the root to the
+// new parent, the parent included; a nil parent moves the category to the root.
+func (c *Category) MoveTo(parentID *string, parentPath []Category) error {
+	if parentID != nil {
+		if *parentID == c.ID {
+			return ErrCategoryCycle
+		}
+
+		inPath := slices.ContainsFunc(parentPath, func(ancestor Category) bool {
+			return ancestor.ID == c.ID
+		})
+		if inPath {
+			return ErrCategoryCycle
+		}
+	}
+
+	c.ParentID = parentID
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AttributeType string
+
+// Attribute value types
+const (
+	AttributeTypeString AttributeType = "string"
+	AttributeTypeNumber AttributeType = "number"
+	AttributeTypeEnum   AttributeType = "enum"
+	AttributeTypeBool   AttributeType = "bool"
+	AttributeTypeDate   AttributeType = "date"
+)
+
+func (t AttributeType) IsValid() bool {
+	switch t {
+	case AttributeTypeString, AttributeTypeNumber, AttributeTypeEnum, AttributeTypeBool, AttributeTypeDate:
+		return true
+	default:
+		return false
+	}
+}
+
+// CategoryAttribute defines an attribute that items of a category may or must have
+type CategoryAttribute struct {
+	ID         string        `json:"id" db:"id"`
+	CategoryID string        `json:"category_id" db:"category_id"`
+	Key        string        `json:"key" db:"key"`
+	Type       AttributeType `json:"type" db:"type"`
+	Required   bool          `json:"required" db:"required"`
+	EnumValues []string      `json:"enum_values" db:"-"`
+	Unit       *string       `json:"unit" db:"unit"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// NormalizeAttributeKey is the form attribute keys are matched and stored in
+func NormalizeAttributeKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+type AttributeViolationReason string
+
+// Reasons an attribute does not match the schema
+const (
+	AttributeUnknownKey   AttributeViolationReason = "unknown_key"
+	AttributeMissing      AttributeViolationReason = "required"
+	AttributeDuplicate    AttributeViolationReason = "duplicate"
+	AttributeEmpty        AttributeViolationReason = "empty"
+	AttributeInvalidValue AttributeViolationReason = "invalid_value"
+)
+
+// AttributeViolation describes why one attribute does not match the schema
+type AttributeViolation struct {
+	Key     string                   `json:"key"`
+	Reason  AttributeViolationReason `json:"reason"`
+	Type    AttributeType            `json:"type,omitempty"`
+	Allowed []string                 `json:"allowed,omitempty"` // Allowed keys or enum values
+}
+
+// AttributeSchema is the union of the attribute definitions of an item's
+// categories, by normalized key. An empty schema accepts any attribute.
+type AttributeSchema map[string]CategoryAttribute
+
+// NewAttributeSchema merges attribute definitions. When several categories
+// define the same key the first definition is used, and the key is required
+// if any of them requires it.
+func NewAttributeSchema(definitions []CategoryAttribute) AttributeSchema {
+	schema := make(AttributeSchema, len(definitions))
+
+	for _, definition := range definitions {
+		key := NormalizeAttributeKey(definition.Key)
+
+		if existing, ok := schema[key]; ok {
+			existing.Required = existing.Required || definition.Required
+			schema[key] = existing
+			continue
+		}
+
+		schema[key] = definition
+	}
+
+	return schema
+}
+
+// Keys returns the defined keys in sorted order
+func (s AttributeSchema) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Validate checks attributes against the schema and returns them with keys
+// and values in canonical form. existing are attributes the item already has;
+// keys may only appear once across both. With complete, the attributes are all
+// the item will have, so required keys must be among them.
+func (s AttributeSchema) Validate(attributes []ItemAttribute, existing []ItemAttribute, complete bool) ([]ItemAttribute, []AttributeViolation) {
+	normalized := make([]ItemAttribute, 0, len(attributes))
+	violations := make([]AttributeViolation, 0)
+
+	if len(s) == 0 {
+		return attributes, duplicateAttributes(attributes, existing)
+	}
+
+	seen := make(map[string]bool, len(attributes)+len(existing))
+	for _, attribute := range existing {
+		seen[NormalizeAttributeKey(attribute.Key)] = true
+	}
+
+	for _, attribute := range attributes {
+		key := NormalizeAttributeKey(attribute.Key)
+
+		definition, ok := s[key]
+		if !ok {
+			violations = append(violations, AttributeViolation{Key: attribute.Key, Reason: AttributeUnknownKey, Allowed: s.Keys()})
+			continue
+		}
+
+		if seen[key] {
+			violations = append(violations, AttributeViolation{Key: key, Reason: AttributeDuplicate})
+			continue
+		}
+		seen[key] = true
+
+		value, violation := definition.normalizeValue(attribute.Value)
+		if violation != nil {
+			violation.Key = key
+			violations = append(violations, *violation)
+			continue
+		}
+
+		attribute.Key = key
+		attribute.Value = value
+		normalized = append(normalized, attribute)
+	}
+
+	if complete {
+		for _, key := range s.Keys() {
+			if s[key].Required && !seen[key] {
+				violations = append(violations, AttributeViolation{Key: key, Reason: AttributeMissing, Type: s[key].Type})
+			}
+		}
+	}
+
+	return normalized, violations
+}
+
+// duplicateAttributes reports keys given more than once, or that the item
+// already has, when there is no schema to normalize them
+func duplicateAttributes(attributes []ItemAttribute, existing []ItemAttribute) []AttributeViolation {
+	violations := make([]AttributeViolation, 0)
+
+	seen := make(map[string]bool, len(attributes)+len(existing))
+	for _, attribute := range existing {
+		seen[attribute.Key] = true
+	}
+
+	for _, attribute := range attributes {
+		if seen[attribute.Key] {
+			violations = append(violations, AttributeViolation{Key: attribute.Key, Reason: AttributeDuplicate})
+			continue
+		}
+		seen[attribute.Key] = true
+	}
+
+	return violations
+}
+
+// normalizeValue parses value according to the attribute type and returns its
+// canonical form
+func (a CategoryAttribute) normalizeValue(value string) (string, *AttributeViolation) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", &AttributeViolation{Reason: AttributeEmpty, Type: a.Type}
+	}
+
+	invalid := &AttributeViolation{Reason: AttributeInvalidValue, Type: a.Type}
+
+	switch a.Type {
+	case AttributeTypeNumber:
+		number, err := decimal.NewFromString(value)
+		if err != nil {
+			return "", invalid
+		}
+		return number.String(), nil
+
+	case AttributeTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", invalid
+		}
+		return strconv.FormatBool(b), nil
+
+	case AttributeTypeDate:
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return "", invalid
+		}
+		return date.Format(time.DateOnly), nil
+
+	case AttributeTypeEnum:
+		i := slices.IndexFunc(a.EnumValues, func(allowed string) bool {
+			return strings.EqualFold(allowed, value)
+		})
+		if i < 0 {
+			invalid.Allowed = a.EnumValues
+			return "", invalid
+		}
+		return a.EnumValues[i], nil
+
+	default:
+		return value, nil
+	}
+}
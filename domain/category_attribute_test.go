@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func testSchema() AttributeSchema {
+	return NewAttributeSchema([]CategoryAttribute{
+		{Key: "Brand", Type: AttributeTypeString, Required: true},
+		{Key: "weight", Type: AttributeTypeNumber},
+		{Key: "boxed", Type: AttributeTypeBool},
+		{Key: "released", Type: AttributeTypeDate},
+		{Key: "condition", Type: AttributeTypeEnum, EnumValues: []string{"New", "Used"}},
+	})
+}
+
+func TestAttributeSchemaValidateValues(t *testing.T) {
+	tests := []struct {
+		key       string
+		value     string
+		want      string
+		wantError AttributeViolationReason
+	}{
+		{" BRAND ", " Acme ", "Acme", ""},
+		{"weight", "1.50", "1.5", ""},
+		{"weight", "heavy", "", AttributeInvalidValue},
+		{"boxed", "1", "true", ""},
+		{"boxed", "maybe", "", AttributeInvalidValue},
+		{"released", "2024-02-29", "2024-02-29", ""},
+		{"released", "2023-02-29", "", AttributeInvalidValue},
+		{"condition", "used", "Used", ""},
+		{"condition", "broken", "", AttributeInvalidValue},
+		{"brand", "  ", "", AttributeEmpty},
+		{"colour", "red", "", AttributeUnknownKey},
+	}
+
+	schema := testSchema()
+	for _, tt := range tests {
+		normalized, violations := schema.Validate([]ItemAttribute{{Key: tt.key, Value: tt.value}}, nil, false)
+
+		if tt.wantError != "" {
+			if len(violations) != 1 || violations[0].Reason != tt.wantError || len(normalized) != 0 {
+				t.Errorf("%s=%q: violations = %+v, want %s", tt.key, tt.value, violations, tt.wantError)
+			}
+			continue
+		}
+
+		if len(violations) != 0 || len(normalized) != 1 {
+			t.Errorf("%s=%q: violations = %+v, want none", tt.key, tt.value, violations)
+			continue
+		}
+		if normalized[0].Key != NormalizeAttributeKey(tt.key) || normalized[0].Value != tt.want {
+			t.Errorf("%s=%q: got %s=%q, want %q", tt.key, tt.value, normalized[0].Key, normalized[0].Value, tt.want)
+		}
+	}
+}
+
+func TestAttributeSchemaValidateViolationDetails(t *testing.T) {
+	schema := testSchema()
+
+	_, violations := schema.Validate([]ItemAttribute{{Key: "colour", Value: "red"}}, nil, false)
+	if len(violations) != 1 || !slices.Equal(violations[0].Allowed, schema.Keys()) || violations[0].Key != "colour" {
+		t.Errorf("unknown key: violations = %+v, want the allowed keys", violations)
+	}
+
+	_, violations = schema.Validate([]ItemAttribute{{Key: "condition", Value: "broken"}}, nil, false)
+	if len(violations) != 1 || !slices.Equal(violations[0].Allowed, []string{"New", "Used"}) || violations[0].Type != AttributeTypeEnum {
+		t.Errorf("invalid enum: violations = %+v, want the allowed values", violations)
+	}
+}
+
+func TestAttributeSchemaValidateKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []ItemAttribute
+		existing   []ItemAttribute
+		complete   bool
+		want       []AttributeViolation
+	}{
+		{
+			name:       "complete",
+			attributes: []ItemAttribute{{Key: "brand", Value: "Acme"}},
+			complete:   true,
+			want:       []AttributeViolation{},
+		},
+		{
+			name:       "required missing",
+			attributes: []ItemAttribute{{Key: "weight", Value: "1"}},
+			complete:   true,
+			want:       []AttributeViolation{{Key: "brand", Reason: AttributeMissing, Type: AttributeTypeString}},
+		},
+		{
+			name:       "required missing in a partial update",
+			attributes: []ItemAttribute{{Key: "weight", Value: "1"}},
+			want:       []AttributeViolation{},
+		},
+		{
+			name:       "required already present",
+			attributes: []ItemAttribute{{Key: "weight", Value: "1"}},
+			existing:   []ItemAttribute{{Key: "brand", Value: "Acme"}},
+			complete:   true,
+			want:       []AttributeViolation{},
+		},
+		{
+			name:       "duplicate in the request",
+			attributes: []ItemAttribute{{Key: "brand", Value: "Acme"}, {Key: "Brand", Value: "Other"}},
+			want:       []AttributeViolation{{Key: "brand", Reason: AttributeDuplicate}},
+		},
+		{
+			name:       "duplicate of an existing attribute",
+			attributes: []ItemAttribute{{Key: "Brand", Value: "Other"}},
+			existing:   []ItemAttribute{{Key: "brand", Value: "Acme"}},
+			want:       []AttributeViolation{{Key: "brand", Reason: AttributeDuplicate}},
+		},
+	}
+
+	schema := testSchema()
+	for _, tt := range tests {
+		_, violations := schema.Validate(tt.attributes, tt.existing, tt.complete)
+
+		if !slices.EqualFunc(violations, tt.want, func(a, b AttributeViolation) bool {
+			return a.Key == b.Key && a.Reason == b.Reason && a.Type == b.Type
+		}) {
+			t.Errorf("%s: violations = %+v, want %+v", tt.name, violations, tt.want)
+		}
+	}
+}
+
+func TestAttributeSchemaValidateWithoutSchema(t *testing.T) {
+	attributes := []ItemAttribute{{Key: "Colour", Value: " red "}, {Key: "size", Value: "L"}}
+
+	normalized, violations := AttributeSchema{}.Validate(attributes, nil, true)
+	if len(violations) != 0 || !slices.Equal(normalized, attributes) {
+		t.Errorf("got %+v with %+v, want the attributes unchanged", normalized, violations)
+	}
+
+	_, violations = AttributeSchema{}.Validate(attributes, []ItemAttribute{{Key: "size", Value: "M"}}, false)
+	if len(violations) != 1 || violations[0].Key != "size" || violations[0].Reason != AttributeDuplicate {
+		t.Errorf("violations = %+v, want size to be a duplicate", violations)
+	}
+}
+
+func TestNewAttributeSchema(t *testing.T) {
+	schema := NewAttributeSchema([]CategoryAttribute{
+		{CategoryID: "parent", Key: "Brand", Type: AttributeTypeString},
+		{CategoryID: "child", Key: "brand", Type: AttributeTypeEnum, Required: true},
+	})
+
+	brand, ok := schema["brand"]
+	if len(schema) != 1 || !ok {
+		t.Fatalf("schema = %+v, want a single brand key", schema)
+	}
+	if brand.CategoryID != "parent" || brand.Type != AttributeTypeString || !brand.Required {
+		t.Errorf("brand = %+v, want the first definition, required", brand)
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoveTo(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	// root → electronics → phones, with laptops at the root
+	root := Category{ID: "root"}
+	electronics := Category{ID: "electronics", ParentID: ptr("root")}
+	phones := Category{ID: "phones", ParentID: ptr("electronics")}
+	laptops := Category{ID: "laptops"}
+
+	tests := []struct {
+		name       string
+		category   Category
+		parentID   *string
+		parentPath []Category
+		wantErr    error
+	}{
+		{"to another branch", phones, ptr("laptops"), []Category{laptops}, nil},
+		{"to the root", phones, nil, nil, nil},
+		{"under its grand parent", phones, ptr("root"), []Category{root}, nil},
+		{"under itself", electronics, ptr("electronics"), []Category{root, electronics}, ErrCategoryCycle},
+		{"under its child", electronics, ptr("phones"), []Category{root, electronics, phones}, ErrCategoryCycle},
+		{"under its grand child", root, ptr("phones"), []Category{root, electronics, phones}, ErrCategoryCycle},
+	}
+
+	for _, tt := range tests {
+		category := tt.category
+		err := category.MoveTo(tt.parentID, tt.parentPath)
+
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		if tt.wantErr != nil {
+			if category.ParentID != tt.category.ParentID {
+				t.Errorf("%s: parent changed on a rejected move", tt.name)
+			}
+			continue
+		}
+
+		if (category.ParentID == nil) != (tt.parentID == nil) || (tt.parentID != nil && *category.ParentID != *tt.parentID) {
+			t.Errorf("%s: parent = %v, want %v", tt.name, category.ParentID, tt.parentID)
+		}
+	}
+}
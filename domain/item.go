@@ -12,7 +12,9 @@ type Item struct {
 	Description  *string `db:"description" json:"description"`
 	SellerID     string  `db:"seller_id" json:"sellerID"`
 	BuyerID      *string `db:"buyer_id" json:"buyerID"`
+	HighBidderID *string `db:"high_bidder_id" json:"-"` // Author of the current highest bid, the buyer if the auction closes sold
 	CurrencyCode string  `db:"currency_code" json:"currencyCode"`
+	Language     *string `db:"language" json:"language"` // Text search configuration of the item, english when nil
 
 	StartPrice   decimal.Decimal  `db:"start_price" json:"startPrice"`
 	CurrentPrice decimal.Decimal  `db:"current_price" json:"currentPrice"`
@@ -25,15 +27,17 @@ type Item struct {
 	StartDate time.Time `db:"start_date" json:"startDate"`
 	EndDate   time.Time `db:"end_date" json:"endDate"`
 
-	Status    string    `db:"status" json:"status"`
-	CreatedAt time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+	Status    ItemStatus `db:"status" json:"status"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"`
 
 	Categories []Category `db:"categories" json:"categories"`
 
 	ExtensionThresholdMinutes *int `db:"extension_threshold_minutes" json:"extensionThresholdMinutes,omitempty"`
 	ExtensionDurationMinutes  *int `db:"extension_duration_minutes" json:"extensionDurationMinutes,omitempty"`
 
+	BidCount int `db:"bid_count" json:"bidCount"`
+
 	Version int `db:"version" json:"version"`
 }
 
@@ -68,3 +72,21 @@ func (i *Item) ShouldExtendForBid(bidTime time.Time) bool {
 func (i *Item) CalculateNewEndDate() time.Time {
 	return i.EndDate.Add(i.GetExtensionDuration())
 }
+
+func (i *Item) HasBids() bool {
+	return i.BidCount > 0
+}
+
+// IsReserveMet reports whether the current price satisfies the reserve price.
+// Items without a reserve are considered met as soon as they receive a bid.
+func (i *Item) IsReserveMet() bool {
+	if !i.HasBids() {
+		return false
+	}
+
+	if i.ReservePrice == nil {
+		return true
+	}
+
+	return i.CurrentPrice.GreaterThanOrEqual(*i.ReservePrice)
+}
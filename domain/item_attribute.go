@@ -10,3 +10,52 @@ type ItemAttribute struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// ItemAttributeChanges is the difference between two attribute sets of an item
+type ItemAttributeChanges struct {
+	Added   []ItemAttribute
+	Updated []ItemAttribute // Existing attributes, IDs kept, with their new value
+	Removed []ItemAttribute
+}
+
+func (c ItemAttributeChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
+// DiffItemAttributes matches existing and desired attributes by key. Keys must
+// be unique within each set.
+func DiffItemAttributes(existing []ItemAttribute, desired []ItemAttribute) ItemAttributeChanges {
+	changes := ItemAttributeChanges{
+		Added:   []ItemAttribute{},
+		Updated: []ItemAttribute{},
+		Removed: []ItemAttribute{},
+	}
+
+	byKey := make(map[string]ItemAttribute, len(existing))
+	for _, attribute := range existing {
+		byKey[attribute.Key] = attribute
+	}
+
+	for _, attribute := range desired {
+		current, ok := byKey[attribute.Key]
+		if !ok {
+			changes.Added = append(changes.Added, attribute)
+			continue
+		}
+		delete(byKey, attribute.Key)
+
+		if current.Value != attribute.Value {
+			current.Value = attribute.Value
+			changes.Updated = append(changes.Updated, current)
+		}
+	}
+
+	// Keep the order of existing for removals
+	for _, attribute := range existing {
+		if _, ok := byKey[attribute.Key]; ok {
+			changes.Removed = append(changes.Removed, attribute)
+		}
+	}
+
+	return changes
+}
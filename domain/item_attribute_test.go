@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffItemAttributes(t *testing.T) {
+	existing := []ItemAttribute{
+		{ID: "1", Key: "brand", Value: "Acme"},
+		{ID: "2", Key: "weight", Value: "1.5"},
+		{ID: "3", Key: "colour", Value: "red"},
+		{ID: "4", Key: "boxed", Value: "true"},
+	}
+	desired := []ItemAttribute{
+		{Key: "size", Value: "L"},
+		{Key: "weight", Value: "2"},
+		{Key: "brand", Value: "Acme"},
+	}
+
+	changes := DiffItemAttributes(existing, desired)
+
+	if !slices.Equal(changes.Added, []ItemAttribute{{Key: "size", Value: "L"}}) {
+		t.Errorf("added = %+v, want size", changes.Added)
+	}
+	if !slices.Equal(changes.Updated, []ItemAttribute{{ID: "2", Key: "weight", Value: "2"}}) {
+		t.Errorf("updated = %+v, want weight with its ID", changes.Updated)
+	}
+	if !slices.Equal(changes.Removed, []ItemAttribute{existing[2], existing[3]}) {
+		t.Errorf("removed = %+v, want colour then boxed", changes.Removed)
+	}
+	if changes.IsEmpty() {
+		t.Error("IsEmpty = true, want false")
+	}
+}
+
+func TestDiffItemAttributesUnchanged(t *testing.T) {
+	existing := []ItemAttribute{{ID: "1", Key: "brand", Value: "Acme"}}
+
+	if changes := DiffItemAttributes(existing, []ItemAttribute{{Key: "brand", Value: "Acme"}}); !changes.IsEmpty() {
+		t.Errorf("changes = %+v, want none", changes)
+	}
+	if changes := DiffItemAttributes(nil, nil); !changes.IsEmpty() {
+		t.Errorf("changes = %+v, want none", changes)
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type BidRejectionReason string
+
+// Bid rejection reasons
+const (
+	BidRejectedItemNotActive   BidRejectionReason = "item_not_active"
+	BidRejectedNotStarted      BidRejectionReason = "auction_not_started"
+	BidRejectedEnded           BidRejectionReason = "auction_ended"
+	BidRejectedBelowStartPrice BidRejectionReason = "below_start_price"
+	BidRejectedBelowMinimum    BidRejectionReason = "below_minimum_bid"
+	BidRejectedInvalidStep     BidRejectionReason = "invalid_bid_increment"
+)
+
+var ErrBidRejected = errors.New("bid rejected")
+
+// BidRejectionError describes why a bid does not satisfy the item's rules
+type BidRejectionError struct {
+	Reason     BidRejectionReason
+	Amount     decimal.Decimal
+	MinimumBid decimal.Decimal
+}
+
+func (e *BidRejectionError) Error() string {
+	return fmt.Sprintf("bid of %s rejected: %s (minimum bid %s)", e.Amount, e.Reason, e.MinimumBid)
+}
+
+func (e *BidRejectionError) Unwrap() error {
+	return ErrBidRejected
+}
+
+// MinimumBid returns the lowest amount the next bid may have: the start price
+// for the first bid, otherwise the current price plus the bid increment.
+func (i *Item) MinimumBid() decimal.Decimal {
+	if !i.HasBids() {
+		return i.StartPrice
+	}
+
+	if i.BidIncrement != nil && i.BidIncrement.IsPositive() {
+		return i.CurrentPrice.Add(*i.BidIncrement)
+	}
+
+	return i.CurrentPrice
+}
+
+// ValidateBid checks a bid of amount placed at the given time against the
+// item's status, auction window, start price and bid increment.
+// Bids must be placed on an active item within [StartDate, EndDate), be at
+// least MinimumBid, beat the current price and, when the item has a bid
+// increment, lie on a step of that increment counted from the start price.
+func (i *Item) ValidateBid(amount decimal.Decimal, at time.Time) error {
+	reject := func(reason BidRejectionReason) error {
+		return &BidRejectionError{Reason: reason, Amount: amount, MinimumBid: i.MinimumBid()}
+	}
+
+	if !i.IsLive() {
+		return reject(BidRejectedItemNotActive)
+	}
+
+	if at.Before(i.StartDate) {
+		return reject(BidRejectedNotStarted)
+	}
+	if !at.Before(i.EndDate) {
+		return reject(BidRejectedEnded)
+	}
+
+	if amount.LessThan(i.StartPrice) {
+		return reject(BidRejectedBelowStartPrice)
+	}
+	if amount.LessThan(i.MinimumBid()) || (i.HasBids() && !amount.GreaterThan(i.CurrentPrice)) {
+		return reject(BidRejectedBelowMinimum)
+	}
+
+	if i.BidIncrement != nil && i.BidIncrement.IsPositive() {
+		if !amount.Sub(i.StartPrice).Mod(*i.BidIncrement).IsZero() {
+			return reject(BidRejectedInvalidStep)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateBid(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	during := start.Add(time.Hour)
+	increment := decimal.NewFromInt(5)
+
+	live := func(bids int, current int64) Item {
+		return Item{
+			Status:       ItemStatusActive,
+			StartPrice:   decimal.NewFromInt(10),
+			CurrentPrice: decimal.NewFromInt(current),
+			BidIncrement: &increment,
+			BidCount:     bids,
+			StartDate:    start,
+			EndDate:      end,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		item   Item
+		amount int64
+		at     time.Time
+		want   BidRejectionReason // Empty when the bid is accepted
+	}{
+		{"first bid at the start price", live(0, 10), 10, during, ""},
+		{"first bid on a step", live(0, 10), 25, during, ""},
+		{"next bid at the minimum", live(1, 20), 25, during, ""},
+		{"bid at the start date", live(0, 10), 10, start, ""},
+		{"scheduled item", Item{Status: ItemStatusScheduled, StartPrice: decimal.NewFromInt(10), StartDate: start, EndDate: end}, 10, during, BidRejectedItemNotActive},
+		{"before the start date", live(0, 10), 10, start.Add(-time.Second), BidRejectedNotStarted},
+		{"at the end date", live(0, 10), 10, end, BidRejectedEnded},
+		{"below the start price", live(0, 10), 5, during, BidRejectedBelowStartPrice},
+		{"below the minimum", live(1, 20), 20, during, BidRejectedBelowMinimum},
+		{"off step", live(0, 10), 12, during, BidRejectedInvalidStep},
+	}
+
+	for _, tt := range tests {
+		err := tt.item.ValidateBid(decimal.NewFromInt(tt.amount), tt.at)
+
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%s: err = %v, want accepted", tt.name, err)
+			}
+			continue
+		}
+
+		var rejection *BidRejectionError
+		if !errors.As(err, &rejection) || !errors.Is(err, ErrBidRejected) {
+			t.Errorf("%s: err = %v, want a BidRejectionError", tt.name, err)
+			continue
+		}
+		if rejection.Reason != tt.want {
+			t.Errorf("%s: reason = %s, want %s", tt.name, rejection.Reason, tt.want)
+		}
+		if !rejection.MinimumBid.Equal(tt.item.MinimumBid()) {
+			t.Errorf("%s: minimum bid = %s, want %s", tt.name, rejection.MinimumBid, tt.item.MinimumBid())
+		}
+	}
+}
+
+func TestValidateBidWithoutIncrement(t *testing.T) {
+	item := Item{
+		Status:       ItemStatusActive,
+		StartPrice:   decimal.NewFromInt(10),
+		CurrentPrice: decimal.NewFromInt(10),
+		BidCount:     1,
+		StartDate:    time.Now().Add(-time.Hour),
+		EndDate:      time.Now().Add(time.Hour),
+	}
+
+	if err := item.ValidateBid(decimal.RequireFromString("10.01"), time.Now()); err != nil {
+		t.Errorf("bid above the current price: err = %v, want accepted", err)
+	}
+
+	var rejection *BidRejectionError
+	if err := item.ValidateBid(decimal.NewFromInt(10), time.Now()); !errors.As(err, &rejection) || rejection.Reason != BidRejectedBelowMinimum {
+		t.Errorf("bid at the current price: err = %v, want %s", err, BidRejectedBelowMinimum)
+	}
+}
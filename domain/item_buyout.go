@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type BuyoutUnavailableReason string
+
+// Reasons a buyout is not available
+const (
+	BuyoutNoPrice         BuyoutUnavailableReason = "no_buyout_price"
+	BuyoutBelowReserve    BuyoutUnavailableReason = "buyout_below_reserve"
+	BuyoutExceededByBids  BuyoutUnavailableReason = "exceeded_by_bids"
+	BuyoutReserveMet      BuyoutUnavailableReason = "reserve_met"
+	BuyoutSellerCannotBuy BuyoutUnavailableReason = "seller_cannot_buy"
+	BuyoutNotStarted      BuyoutUnavailableReason = "not_started"
+	BuyoutEnded           BuyoutUnavailableReason = "auction_ended"
+)
+
+var ErrBuyoutUnavailable = errors.New("buyout unavailable")
+
+// BuyoutError describes why an item cannot be bought out
+type BuyoutError struct {
+	Reason BuyoutUnavailableReason
+}
+
+func (e *BuyoutError) Error() string {
+	return fmt.Sprintf("buyout unavailable: %s", e.Reason)
+}
+
+func (e *BuyoutError) Unwrap() error {
+	return ErrBuyoutUnavailable
+}
+
+// CanBuyout reports whether buyerID may buy the item at its buyout price at
+// the given time. Like bids, buyouts are only taken between the start and end
+// dates. Buyout disappears once bidding reaches the buyout price and, for
+// items with a reserve, once the reserve has been met. A buyout price below
+// the reserve is never offered since it would sell the item under the
+// seller's minimum.
+func (i *Item) CanBuyout(buyerID string, at time.Time) error {
+	if i.BuyoutPrice == nil {
+		return &BuyoutError{Reason: BuyoutNoPrice}
+	}
+
+	if at.Before(i.StartDate) {
+		return &BuyoutError{Reason: BuyoutNotStarted}
+	}
+	if !at.Before(i.EndDate) {
+		return &BuyoutError{Reason: BuyoutEnded}
+	}
+
+	if buyerID == i.SellerID {
+		return &BuyoutError{Reason: BuyoutSellerCannotBuy}
+	}
+
+	if i.ReservePrice != nil {
+		if i.BuyoutPrice.LessThan(*i.ReservePrice) {
+			return &BuyoutError{Reason: BuyoutBelowReserve}
+		}
+	}
+
+	if i.HasBids() && i.CurrentPrice.GreaterThanOrEqual(*i.BuyoutPrice) {
+		return &BuyoutError{Reason: BuyoutExceededByBids}
+	}
+
+	if i.ReservePrice != nil && i.IsReserveMet() {
+		return &BuyoutError{Reason: BuyoutReserveMet}
+	}
+
+	return nil
+}
+
+// Buyout ends a live auction at the given time and sells the item to buyerID
+// at its buyout price, going through the ended status like a regular close.
+func (i *Item) Buyout(buyerID string, at time.Time) error {
+	if !i.Status.CanTransitionTo(ItemStatusEnded) {
+		return &TransitionError{From: i.Status, To: ItemStatusSold, Reason: "only active items can be bought out"}
+	}
+
+	if err := i.CanBuyout(buyerID, at); err != nil {
+		return err
+	}
+
+	if err := i.TransitionTo(ItemStatusEnded); err != nil {
+		return err
+	}
+	if err := i.TransitionTo(ItemStatusSold); err != nil {
+		return err
+	}
+
+	price := *i.BuyoutPrice
+	i.BuyerID = &buyerID
+	i.CurrentPrice = price
+	i.EndPrice = &price
+	i.EndDate = at
+	i.UpdatedAt = at
+
+	return nil
+}
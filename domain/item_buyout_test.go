@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCanBuyout(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	during := start.Add(time.Hour)
+
+	price := func(amount int64) *decimal.Decimal {
+		d := decimal.NewFromInt(amount)
+		return &d
+	}
+	item := func(buyout, reserve *decimal.Decimal, bids int, current int64) Item {
+		return Item{
+			SellerID:     "seller",
+			Status:       ItemStatusActive,
+			StartPrice:   decimal.NewFromInt(10),
+			CurrentPrice: decimal.NewFromInt(current),
+			BuyoutPrice:  buyout,
+			ReservePrice: reserve,
+			BidCount:     bids,
+			StartDate:    start,
+			EndDate:      end,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		item  Item
+		buyer string
+		at    time.Time
+		want  BuyoutUnavailableReason // Empty when the buyout is available
+	}{
+		{"no bids", item(price(100), nil, 0, 10), "buyer", during, ""},
+		{"bids below the buyout price", item(price(100), nil, 3, 90), "buyer", during, ""},
+		{"reserve not met yet", item(price(100), price(80), 1, 50), "buyer", during, ""},
+		{"at the start date", item(price(100), nil, 0, 10), "buyer", start, ""},
+		{"no buyout price", item(nil, nil, 0, 10), "buyer", during, BuyoutNoPrice},
+		{"seller", item(price(100), nil, 0, 10), "seller", during, BuyoutSellerCannotBuy},
+		{"before the start date", item(price(100), nil, 0, 10), "buyer", start.Add(-time.Second), BuyoutNotStarted},
+		{"at the end date", item(price(100), nil, 0, 10), "buyer", end, BuyoutEnded},
+		{"buyout below the reserve", item(price(50), price(80), 0, 10), "buyer", during, BuyoutBelowReserve},
+		{"exceeded by bids", item(price(100), nil, 4, 100), "buyer", during, BuyoutExceededByBids},
+		{"reserve met", item(price(100), price(80), 2, 80), "buyer", during, BuyoutReserveMet},
+	}
+
+	for _, tt := range tests {
+		err := tt.item.CanBuyout(tt.buyer, tt.at)
+
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%s: err = %v, want available", tt.name, err)
+			}
+			continue
+		}
+
+		var buyoutErr *BuyoutError
+		if !errors.As(err, &buyoutErr) || !errors.Is(err, ErrBuyoutUnavailable) || buyoutErr.Reason != tt.want {
+			t.Errorf("%s: err = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestBuyout(t *testing.T) {
+	buyout := decimal.NewFromInt(100)
+	at := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	item := Item{
+		SellerID:     "seller",
+		Status:       ItemStatusActive,
+		StartPrice:   decimal.NewFromInt(10),
+		CurrentPrice: decimal.NewFromInt(40),
+		BuyoutPrice:  &buyout,
+		BidCount:     2,
+		StartDate:    at.Add(-time.Hour),
+		EndDate:      at.Add(time.Hour),
+	}
+
+	if err := item.Buyout("buyer", at); err != nil {
+		t.Fatalf("Buyout: %v", err)
+	}
+
+	if item.Status != ItemStatusSold {
+		t.Errorf("status = %s, want sold", item.Status)
+	}
+	if item.BuyerID == nil || *item.BuyerID != "buyer" {
+		t.Errorf("buyer = %v, want buyer", item.BuyerID)
+	}
+	if !item.CurrentPrice.Equal(buyout) || item.EndPrice == nil || !item.EndPrice.Equal(buyout) {
+		t.Errorf("prices = %s/%v, want the buyout price", item.CurrentPrice, item.EndPrice)
+	}
+	if !item.EndDate.Equal(at) {
+		t.Errorf("end date = %s, want %s", item.EndDate, at)
+	}
+}
+
+func TestBuyoutRejected(t *testing.T) {
+	buyout := decimal.NewFromInt(100)
+	at := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	base := Item{
+		SellerID:    "seller",
+		BuyoutPrice: &buyout,
+		StartDate:   at.Add(-time.Hour),
+		EndDate:     at.Add(time.Hour),
+	}
+
+	scheduled := base
+	scheduled.Status = ItemStatusScheduled
+	if err := scheduled.Buyout("buyer", at); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("scheduled item: err = %v, want %v", err, ErrIllegalTransition)
+	}
+
+	expired := base
+	expired.Status = ItemStatusActive
+	if err := expired.Buyout("buyer", base.EndDate); !errors.Is(err, ErrBuyoutUnavailable) || expired.Status != ItemStatusActive {
+		t.Errorf("expired item: err = %v, status = %s; want %v and unchanged", err, expired.Status, ErrBuyoutUnavailable)
+	}
+}
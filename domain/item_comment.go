@@ -1,13 +1,156 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+type CommentStatus string
+
+// Comment statuses. Comments that are not visible stay in their thread as
+// tombstones so replies keep their place.
+const (
+	CommentStatusVisible CommentStatus = "visible"
+	CommentStatusPending CommentStatus = "pending" // Held for review by a moderator
+	CommentStatusHidden  CommentStatus = "hidden"  // Hidden by a moderator or the seller
+	CommentStatusDeleted CommentStatus = "deleted" // Deleted by the author
+)
+
+var ErrCommentDeleted = errors.New("comment is deleted")
+
+type CommentKind string
+
+// Comment kinds. Questions are asked on an item and answered publicly by its
+// seller; answers are replies to a question.
+const (
+	CommentKindComment  CommentKind = "comment"
+	CommentKindQuestion CommentKind = "question"
+	CommentKindAnswer   CommentKind = "answer"
+)
 
 type ItemComment struct {
+	ID               string        `json:"id" db:"id"`
+	ItemID           string        `json:"item_id" db:"item_id"`
+	Content          string        `json:"content" db:"content"`
+	UserID           string        `json:"user_id" db:"user_id"`
+	ParentID         *string       `json:"parent_id" db:"parent_id"`
+	Kind             CommentKind   `json:"kind" db:"kind"`
+	Status           CommentStatus `json:"status" db:"status"`
+	Edited           bool          `json:"edited" db:"edited"`
+	EditedAt         *time.Time    `json:"edited_at" db:"edited_at"`
+	ModeratedBy      *string       `json:"moderated_by" db:"moderated_by"`
+	ModeratedAt      *time.Time    `json:"moderated_at" db:"moderated_at"`
+	ModerationReason *string       `json:"moderation_reason" db:"moderation_reason"`
+	DeletedAt        *time.Time    `json:"deleted_at" db:"deleted_at"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+func (c ItemComment) IsQuestion() bool {
+	return c.Kind == CommentKindQuestion
+}
+
+func (c ItemComment) IsAnswer() bool {
+	return c.Kind == CommentKindAnswer
+}
+
+func (c ItemComment) IsVisible() bool {
+	return c.Status == CommentStatusVisible
+}
+
+func (c ItemComment) IsDeleted() bool {
+	return c.Status == CommentStatusDeleted
+}
+
+// Redacted returns the comment as shown to everyone: tombstones lose their
+// content and moderation details stay with moderators
+func (c ItemComment) Redacted() ItemComment {
+	if !c.IsVisible() {
+		c.Content = ""
+	}
+	c.ModeratedBy = nil
+	c.ModerationReason = nil
+
+	return c
+}
+
+// Moderate moves the comment to status on behalf of moderatorID, nil for
+// automatic moderation. It reports whether the status changed.
+func (c *ItemComment) Moderate(status CommentStatus, moderatorID *string, reason *string, now time.Time) (bool, error) {
+	if c.IsDeleted() {
+		return false, ErrCommentDeleted
+	}
+
+	if c.Status == status {
+		return false, nil
+	}
+
+	c.Status = status
+	c.ModeratedBy = moderatorID
+	c.ModeratedAt = &now
+	c.ModerationReason = reason
+
+	return true, nil
+}
+
+// Delete turns the comment into a tombstone. It reports whether the comment
+// was deleted by this call.
+func (c *ItemComment) Delete(now time.Time) bool {
+	if c.IsDeleted() {
+		return false
+	}
+
+	c.Status = CommentStatusDeleted
+	c.DeletedAt = &now
+
+	return true
+}
+
+// EditableUntil returns when the author can no longer edit the comment. A
+// window of zero or less never closes.
+func (c ItemComment) EditableUntil(window time.Duration) *time.Time {
+	if window <= 0 {
+		return nil
+	}
+
+	until := c.CreatedAt.Add(window)
+
+	return &until
+}
+
+// IsEditable reports whether the author can still edit the comment at now
+func (c ItemComment) IsEditable(now time.Time, window time.Duration) bool {
+	until := c.EditableUntil(window)
+
+	return until == nil || now.Before(*until)
+}
+
+// ItemCommentRevision is the content a comment had before an edit
+type ItemCommentRevision struct {
 	ID        string    `json:"id" db:"id"`
-	ItemID    string    `json:"item_id" db:"item_id"`
+	CommentID string    `json:"comment_id" db:"comment_id"`
 	Content   string    `json:"content" db:"content"`
-	UserID    string    `json:"user_id" db:"user_id"`
-	ParentID  *string   `json:"parent_id" db:"parent_id"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	EditedBy  string    `json:"edited_by" db:"edited_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // When the content was replaced
+}
+
+type CommentReportReason string
+
+// Reasons users can report a comment for
+const (
+	CommentReportSpam     CommentReportReason = "spam"
+	CommentReportAbuse    CommentReportReason = "abuse"
+	CommentReportOffTopic CommentReportReason = "off_topic"
+	CommentReportOther    CommentReportReason = "other"
+)
+
+// ItemCommentReport is a user's report of a comment. Each user can report a
+// comment once.
+type ItemCommentReport struct {
+	ID         string              `json:"id" db:"id"`
+	CommentID  string              `json:"comment_id" db:"comment_id"`
+	ReporterID string              `json:"reporter_id" db:"reporter_id"`
+	Reason     CommentReportReason `json:"reason" db:"reason"`
+	Details    *string             `json:"details" db:"details"`
+	CreatedAt  time.Time           `json:"created_at" db:"created_at"`
 }
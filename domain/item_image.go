@@ -7,6 +7,38 @@ type ItemImage struct {
 	ItemID       string    `json:"item_id" db:"item_id"`
 	ImageURL     string    `json:"url" db:"url"`
 	DisplayOrder int       `json:"display_order" db:"display_order"`
+	IsPrimary    bool      `json:"is_primary" db:"is_primary"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// ItemImageUpload is an image the seller uploads straight to storage with a
+// presigned URL. It becomes an ItemImage with the same id once completed.
+type ItemImageUpload struct {
+	ID          string    `json:"id" db:"id"`
+	ItemID      string    `json:"item_id" db:"item_id"`
+	ObjectKey   string    `json:"object_key" db:"object_key"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsExpired reports whether the upload URL is no longer valid at now
+func (u ItemImageUpload) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// ItemImageVariant is a scaled down rendition of an image in one format,
+// rendered by the worker after the image is uploaded
+type ItemImageVariant struct {
+	ID        string    `json:"id" db:"id"`
+	ImageID   string    `json:"image_id" db:"image_id"`
+	Size      string    `json:"size" db:"size"` // Name of the rendition, e.g. thumb
+	Format    string    `json:"format" db:"format"`
+	ImageURL  string    `json:"url" db:"url"`
+	Width     int       `json:"width" db:"width"`
+	Height    int       `json:"height" db:"height"`
+	Bytes     int64     `json:"bytes" db:"bytes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ItemStatus string
+
+// Item statuses
+const (
+	ItemStatusDraft     ItemStatus = "draft"
+	ItemStatusScheduled ItemStatus = "scheduled"
+	ItemStatusActive    ItemStatus = "active"
+	ItemStatusEnded     ItemStatus = "ended"
+	ItemStatusSold      ItemStatus = "sold"
+	ItemStatusUnsold    ItemStatus = "unsold"
+	ItemStatusCancelled ItemStatus = "cancelled"
+)
+
+// ListedItemStatuses are the statuses of items shown in public listings and
+// search results. Drafts and cancelled items are only seen by their seller.
+var ListedItemStatuses = []ItemStatus{
+	ItemStatusScheduled,
+	ItemStatusActive,
+	ItemStatusEnded,
+	ItemStatusSold,
+	ItemStatusUnsold,
+}
+
+// itemTransitions lists the statuses each status may move to.
+// Statuses without an entry are terminal. A draft always goes live through
+// scheduled, see Publish. unsold → sold only exists for bid.won: the bid
+// service decides the outcome, and overrides the closer when a late bid made
+// it settle the item as unsold, see SettleWon.
+var itemTransitions = map[ItemStatus][]ItemStatus{
+	ItemStatusDraft:     {ItemStatusScheduled, ItemStatusCancelled},
+	ItemStatusScheduled: {ItemStatusDraft, ItemStatusActive, ItemStatusCancelled},
+	ItemStatusActive:    {ItemStatusEnded, ItemStatusCancelled},
+	ItemStatusEnded:     {ItemStatusSold, ItemStatusUnsold},
+	ItemStatusUnsold:    {ItemStatusSold},
+}
+
+var ErrIllegalTransition = errors.New("illegal item status transition")
+
+// TransitionError describes a rejected status change
+type TransitionError struct {
+	From   ItemStatus
+	To     ItemStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("cannot move item from %s to %s: %s", e.From, e.To, e.Reason)
+	}
+
+	return fmt.Sprintf("cannot move item from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// IsValid reports whether s is one of the known statuses
+func (s ItemStatus) IsValid() bool {
+	switch s {
+	case ItemStatusDraft, ItemStatusScheduled, ItemStatusActive, ItemStatusEnded,
+		ItemStatusSold, ItemStatusUnsold, ItemStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo reports whether the transition table allows moving to next
+func (s ItemStatus) CanTransitionTo(next ItemStatus) bool {
+	for _, allowed := range itemTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionTo moves the item to next, enforcing the transition table and
+// the rule that a live auction can only be cancelled before its first bid.
+func (i *Item) TransitionTo(next ItemStatus) error {
+	if !i.Status.CanTransitionTo(next) {
+		return &TransitionError{From: i.Status, To: next}
+	}
+
+	if next == ItemStatusCancelled && i.HasBids() {
+		return &TransitionError{From: i.Status, To: next, Reason: "the item already has bids"}
+	}
+
+	i.Status = next
+
+	return nil
+}
+
+// Close ends a live auction and settles it as sold to the highest bidder or
+// unsold
+func (i *Item) Close() error {
+	if err := i.TransitionTo(ItemStatusEnded); err != nil {
+		return err
+	}
+
+	if err := i.TransitionTo(i.CloseOutcome()); err != nil {
+		return err
+	}
+
+	if i.Status == ItemStatusSold {
+		i.BuyerID = i.HighBidderID
+	}
+
+	return nil
+}
+
+// SettleWon settles the item as sold once the bid service reports a winner.
+// The bid service is authoritative on the outcome, so an item the auction
+// closer already settled as unsold, e.g. because a bid.placed arrived late, is
+// reconciled to sold. Items that never went live or were cancelled are
+// rejected with a TransitionError.
+func (i *Item) SettleWon() error {
+	switch i.Status {
+	case ItemStatusActive:
+		if err := i.TransitionTo(ItemStatusEnded); err != nil {
+			return err
+		}
+
+		return i.TransitionTo(ItemStatusSold)
+	case ItemStatusEnded, ItemStatusUnsold:
+		return i.TransitionTo(ItemStatusSold)
+	case ItemStatusSold:
+		return nil
+	default:
+		return &TransitionError{From: i.Status, To: ItemStatusSold, Reason: "the auction never ran"}
+	}
+}
+
+// PublishStatus returns the status a published item should have: scheduled
+// while its start date is in the future, active otherwise.
+func PublishStatus(startDate, now time.Time) ItemStatus {
+	if startDate.After(now) {
+		return ItemStatusScheduled
+	}
+
+	return ItemStatusActive
+}
+
+// Publish schedules a draft or scheduled item and opens it right away when
+// its start date has passed, following draft → scheduled → active.
+func (i *Item) Publish(now time.Time) error {
+	if i.Status == ItemStatusDraft {
+		if err := i.TransitionTo(ItemStatusScheduled); err != nil {
+			return err
+		}
+	}
+
+	if next := PublishStatus(i.StartDate, now); next != i.Status {
+		return i.TransitionTo(next)
+	}
+
+	return nil
+}
+
+// IsLive reports whether bidding has opened on the item
+func (i *Item) IsLive() bool {
+	return i.Status == ItemStatusActive
+}
+
+// IsFinished reports whether the item can no longer change
+func (i *Item) IsFinished() bool {
+	switch i.Status {
+	case ItemStatusEnded, ItemStatusSold, ItemStatusUnsold, ItemStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// CloseOutcome returns the status an ended auction settles in
+func (i *Item) CloseOutcome() ItemStatus {
+	if i.IsReserveMet() {
+		return ItemStatusSold
+	}
+
+	return ItemStatusUnsold
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTransitionTo(t *testing.T) {
+	allowed := map[ItemStatus][]ItemStatus{
+		ItemStatusDraft:     {ItemStatusScheduled, ItemStatusCancelled},
+		ItemStatusScheduled: {ItemStatusDraft, ItemStatusActive, ItemStatusCancelled},
+		ItemStatusActive:    {ItemStatusEnded, ItemStatusCancelled},
+		ItemStatusEnded:     {ItemStatusSold, ItemStatusUnsold},
+		ItemStatusUnsold:    {ItemStatusSold},
+	}
+	statuses := []ItemStatus{
+		ItemStatusDraft, ItemStatusScheduled, ItemStatusActive, ItemStatusEnded,
+		ItemStatusSold, ItemStatusUnsold, ItemStatusCancelled,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, next := range allowed[from] {
+				want = want || next == to
+			}
+
+			item := Item{Status: from}
+			err := item.TransitionTo(to)
+
+			if want {
+				if err != nil || item.Status != to {
+					t.Errorf("%s → %s: err = %v, status = %s; want allowed", from, to, err, item.Status)
+				}
+				continue
+			}
+
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) || !errors.Is(err, ErrIllegalTransition) {
+				t.Errorf("%s → %s: err = %v, want a TransitionError", from, to, err)
+				continue
+			}
+			if transitionErr.From != from || transitionErr.To != to || item.Status != from {
+				t.Errorf("%s → %s: error %+v, status = %s", from, to, transitionErr, item.Status)
+			}
+		}
+	}
+}
+
+func TestTransitionToCancelledAfterBids(t *testing.T) {
+	item := Item{Status: ItemStatusActive, BidCount: 1}
+
+	var transitionErr *TransitionError
+	if err := item.TransitionTo(ItemStatusCancelled); !errors.As(err, &transitionErr) || transitionErr.Reason == "" {
+		t.Fatalf("err = %v, want a TransitionError with a reason", err)
+	}
+	if item.Status != ItemStatusActive {
+		t.Errorf("status = %s, want active", item.Status)
+	}
+}
+
+func TestClose(t *testing.T) {
+	reserve := decimal.NewFromInt(100)
+	bidder := "bidder-1"
+
+	tests := []struct {
+		name      string
+		item      Item
+		want      ItemStatus
+		wantBuyer bool
+	}{
+		{"no bids", Item{Status: ItemStatusActive}, ItemStatusUnsold, false},
+		{"bids without reserve", Item{Status: ItemStatusActive, BidCount: 1, CurrentPrice: decimal.NewFromInt(5), HighBidderID: &bidder}, ItemStatusSold, true},
+		{"reserve missed", Item{Status: ItemStatusActive, BidCount: 2, CurrentPrice: decimal.NewFromInt(99), ReservePrice: &reserve, HighBidderID: &bidder}, ItemStatusUnsold, false},
+		{"reserve met", Item{Status: ItemStatusActive, BidCount: 2, CurrentPrice: decimal.NewFromInt(100), ReservePrice: &reserve, HighBidderID: &bidder}, ItemStatusSold, true},
+	}
+
+	for _, tt := range tests {
+		item := tt.item
+		if err := item.Close(); err != nil {
+			t.Fatalf("%s: Close: %v", tt.name, err)
+		}
+
+		if item.Status != tt.want {
+			t.Errorf("%s: status = %s, want %s", tt.name, item.Status, tt.want)
+		}
+		if gotBuyer := item.BuyerID != nil && *item.BuyerID == bidder; gotBuyer != tt.wantBuyer {
+			t.Errorf("%s: buyer = %v, want the high bidder: %v", tt.name, item.BuyerID, tt.wantBuyer)
+		}
+	}
+
+	item := Item{Status: ItemStatusScheduled}
+	if err := item.Close(); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Close of a scheduled item: err = %v, want %v", err, ErrIllegalTransition)
+	}
+}
+
+func TestSettleWon(t *testing.T) {
+	tests := []struct {
+		from    ItemStatus
+		wantErr bool
+	}{
+		{ItemStatusActive, false},
+		{ItemStatusEnded, false},
+		{ItemStatusSold, false},
+		{ItemStatusUnsold, false},
+		{ItemStatusDraft, true},
+		{ItemStatusScheduled, true},
+		{ItemStatusCancelled, true},
+	}
+
+	for _, tt := range tests {
+		item := Item{Status: tt.from}
+		err := item.SettleWon()
+
+		if tt.wantErr {
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) || item.Status != tt.from {
+				t.Errorf("%s: err = %v, status = %s; want a TransitionError", tt.from, err, item.Status)
+			}
+			continue
+		}
+
+		if err != nil || item.Status != ItemStatusSold {
+			t.Errorf("%s: err = %v, status = %s; want sold", tt.from, err, item.Status)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    ItemStatus
+		startDate time.Time
+		want      ItemStatus
+	}{
+		{"draft starting later", ItemStatusDraft, now.Add(time.Hour), ItemStatusScheduled},
+		{"draft already started", ItemStatusDraft, now.Add(-time.Hour), ItemStatusActive},
+		{"draft starting now", ItemStatusDraft, now, ItemStatusActive},
+		{"scheduled starting later", ItemStatusScheduled, now.Add(time.Hour), ItemStatusScheduled},
+		{"scheduled already started", ItemStatusScheduled, now.Add(-time.Hour), ItemStatusActive},
+	}
+
+	for _, tt := range tests {
+		item := Item{Status: tt.status, StartDate: tt.startDate}
+		if err := item.Publish(now); err != nil || item.Status != tt.want {
+			t.Errorf("%s: err = %v, status = %s; want %s", tt.name, err, item.Status, tt.want)
+		}
+	}
+
+	item := Item{Status: ItemStatusCancelled, StartDate: now}
+	if err := item.Publish(now); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Publish of a cancelled item: err = %v, want %v", err, ErrIllegalTransition)
+	}
+}
@@ -1,12 +1,24 @@
 package grpc
 
 import (
+	itemv1 "auction/proto/gen"
 	"context"
 	"log"
+	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// userMethods act on behalf of a user. Like the security headers of the HTTP
+// API, the user comes from the user-id, user-email and authorization metadata
+// set by the gateway, never from the request message.
+var userMethods = map[string]bool{
+	itemv1.ItemService_Buyout_FullMethodName: true,
+}
+
 func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	log.Printf("Received request: %s", info.FullMethod)
 	resp, err := handler(ctx, req)
@@ -16,6 +28,38 @@ func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnarySe
 	return resp, err
 }
 
+// userMetadataInterceptor stores the user of the call metadata in the context
+// of userMethods and rejects calls without it
+func userMetadataInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !userMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	userID := firstMetadataValue(md, "user-id")
+	userEmail := firstMetadataValue(md, "user-email")
+	authorization := firstMetadataValue(md, "authorization")
+
+	if userID == "" || userEmail == "" || authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "user-id, user-email and authorization metadata are required")
+	}
+
+	ctx = context.WithValue(ctx, "UserID", userID)
+	ctx = context.WithValue(ctx, "UserEmail", userEmail)
+	ctx = context.WithValue(ctx, "Jwt", authorization)
+
+	return handler(ctx, req)
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0])
+}
+
 func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	defer func() {
 		if r := recover(); r != nil {
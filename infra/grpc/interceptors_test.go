@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	itemv1 "auction/proto/gen"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserMetadataInterceptor(t *testing.T) {
+	user := metadata.Pairs("user-id", "buyer", "user-email", "buyer@example.com", "authorization", "Bearer token")
+
+	tests := []struct {
+		name     string
+		method   string
+		md       metadata.MD
+		wantUser string
+		wantCode codes.Code
+	}{
+		{"user method with metadata", itemv1.ItemService_Buyout_FullMethodName, user, "buyer", codes.OK},
+		{"user method without metadata", itemv1.ItemService_Buyout_FullMethodName, nil, "", codes.Unauthenticated},
+		{"user method with partial metadata", itemv1.ItemService_Buyout_FullMethodName, metadata.Pairs("user-id", "buyer"), "", codes.Unauthenticated},
+		{"other method", itemv1.ItemService_GetItemForBid_FullMethodName, nil, "", codes.OK},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.md != nil {
+			ctx = metadata.NewIncomingContext(ctx, tt.md)
+		}
+
+		var gotUser string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			gotUser, _ = ctx.Value("UserID").(string)
+			return nil, nil
+		}
+
+		_, err := userMetadataInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+		if code := status.Code(err); code != tt.wantCode {
+			t.Errorf("%s: code = %s, want %s", tt.name, code, tt.wantCode)
+		}
+		if gotUser != tt.wantUser {
+			t.Errorf("%s: user = %q, want %q", tt.name, gotUser, tt.wantUser)
+		}
+	}
+}
+
+func TestBuyoutRejectsAnotherBuyer(t *testing.T) {
+	server := &ItemServiceServer{}
+	ctx := context.WithValue(context.Background(), "UserID", "buyer")
+
+	_, err := server.Buyout(ctx, &itemv1.BuyoutRequest{ItemId: "item", BuyerId: "someone-else"})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("code = %s, want %s", code, codes.PermissionDenied)
+	}
+
+	_, err = server.Buyout(context.Background(), &itemv1.BuyoutRequest{ItemId: "item"})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Errorf("without a user: code = %s, want %s", code, codes.Unauthenticated)
+	}
+}
@@ -2,10 +2,14 @@ package grpc
 
 import (
 	"auction/app"
+	"auction/domain"
+	"auction/pkg/events"
+	"auction/pkg/httperror"
 	itemv1 "auction/proto/gen"
 	"context"
 	"database/sql"
 	"errors"
+	"net/http"
 
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc/codes"
@@ -15,12 +19,14 @@ import (
 
 type ItemServiceServer struct {
 	itemv1.UnimplementedItemServiceServer
-	repository app.Repository
+	repository    app.Repository
+	buyoutHandler *app.BuyoutItemHandler
 }
 
-func NewItemServiceServer(repository app.Repository) *ItemServiceServer {
+func NewItemServiceServer(repository app.Repository, eventPublisher events.Publisher) *ItemServiceServer {
 	return &ItemServiceServer{
-		repository: repository,
+		repository:    repository,
+		buyoutHandler: app.NewBuyoutItemHandler(repository, eventPublisher),
 	}
 }
 
@@ -37,17 +43,51 @@ func (s *ItemServiceServer) GetItemForBid(ctx context.Context, req *itemv1.GetIt
 	return &itemv1.GetItemForBidResponse{
 		Id:           item.ID,
 		SellerId:     item.SellerID,
-		Status:       item.Status,
+		Status:       string(item.Status),
 		StartDate:    timestamppb.New(item.StartDate),
 		EndDate:      timestamppb.New(item.EndDate),
 		StartPrice:   item.StartPrice.String(),
 		CurrentPrice: item.CurrentPrice.String(),
 		BidIncrement: decimalToString(item.BidIncrement),
-		ReservePrice: decimalToString(item.ReservePrice),
 		BuyoutPrice:  decimalToString(item.BuyoutPrice),
 		EndPrice:     decimalToString(item.EndPrice),
 		CreatedAt:    timestamppb.New(item.CreatedAt),
 		UpdatedAt:    timestamppb.New(item.UpdatedAt),
+		HasReserve:   item.ReservePrice != nil,
+		ReserveMet:   item.IsReserveMet(),
+	}, nil
+}
+
+func (s *ItemServiceServer) Buyout(ctx context.Context, req *itemv1.BuyoutRequest) (*itemv1.BuyoutResponse, error) {
+	if req.ItemId == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	// The buyer is the user of the call metadata, see userMetadataInterceptor.
+	// buyer_id is only accepted when it names that user.
+	buyerID, _ := ctx.Value("UserID").(string)
+	if buyerID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user-id metadata is required")
+	}
+	if req.BuyerId != "" && req.BuyerId != buyerID {
+		return nil, status.Error(codes.PermissionDenied, "buyer_id must be the calling user")
+	}
+
+	res, err := s.buyoutHandler.Handle(ctx, &app.BuyoutItemRequest{ItemID: req.ItemId})
+	if err != nil {
+		return nil, mapHTTPError(err)
+	}
+
+	item := res.Item
+
+	return &itemv1.BuyoutResponse{
+		Id:           item.ID,
+		SellerId:     item.SellerID,
+		BuyerId:      buyerID,
+		Status:       string(item.Status),
+		CurrencyCode: item.CurrencyCode,
+		FinalPrice:   decimalToString(item.EndPrice),
+		SoldAt:       timestamppb.New(item.UpdatedAt),
 	}, nil
 }
 
@@ -64,3 +104,32 @@ func decimalToString(d *decimal.Decimal) string {
 	}
 	return d.String()
 }
+
+// mapHTTPError converts an error returned by an app handler into a gRPC status
+func mapHTTPError(err error) error {
+	var httpErr *httperror.Error
+	if !errors.As(err, &httpErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	var transitionErr *domain.TransitionError
+	var buyoutErr *domain.BuyoutError
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, app.ErrOptimisticLock):
+		code = codes.Aborted // The client may retry
+	case errors.As(err, &transitionErr), errors.As(err, &buyoutErr):
+		code = codes.FailedPrecondition
+	case httpErr.Status == http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case httpErr.Status == http.StatusNotFound:
+		code = codes.NotFound
+	case httpErr.Status == http.StatusConflict:
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Internal
+	}
+
+	return status.Error(code, httpErr.Error())
+}
@@ -30,6 +30,7 @@ func NewServer(cfg *config.AppConfig) (*Server, error) {
 		grpc.ChainUnaryInterceptor(
 			loggingInterceptor,
 			recoveryInterceptor,
+			userMetadataInterceptor,
 		),
 	)
 
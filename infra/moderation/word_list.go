@@ -0,0 +1,51 @@
+package moderation
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+	"strings"
+	"unicode"
+)
+
+// WordListModerator holds comments that contain any of a list of words or
+// phrases for review. Matching ignores case, punctuation and spacing, and only
+// matches whole words.
+type WordListModerator struct {
+	phrases []string
+}
+
+func NewWordListModerator(words []string) *WordListModerator {
+	phrases := make([]string, 0, len(words))
+	for _, word := range words {
+		if phrase := normalize(word); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	return &WordListModerator{
+		phrases: phrases,
+	}
+}
+
+func (m *WordListModerator) ReviewComment(ctx context.Context, comment domain.ItemComment) (app.ModerationDecision, error) {
+	// Padded so phrases only match at word boundaries
+	content := " " + normalize(comment.Content) + " "
+
+	for _, phrase := range m.phrases {
+		if strings.Contains(content, " "+phrase+" ") {
+			return app.ModerationDecision{Verdict: app.ModerationHold, Reason: "word_list"}, nil
+		}
+	}
+
+	return app.ModerationDecision{Verdict: app.ModerationApprove}, nil
+}
+
+// normalize lowercases text and reduces it to its words separated by single spaces
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, " ")
+}
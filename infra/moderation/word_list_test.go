@@ -0,0 +1,49 @@
+package moderation
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+	"testing"
+)
+
+func TestWordListModerator(t *testing.T) {
+	moderator := NewWordListModerator([]string{"Scam", "  wire   transfer ", "", "!!!"})
+
+	tests := []struct {
+		content string
+		want    app.ModerationVerdict
+	}{
+		{"Looks like a great camera", app.ModerationApprove},
+		{"This is a SCAM!", app.ModerationHold},
+		{"scam", app.ModerationHold},
+		{"Pay by wire-transfer only", app.ModerationHold},
+		{"Pay by WIRE\n\ttransfer", app.ModerationHold},
+		{"Scammers beware", app.ModerationApprove},
+		{"No wire, transfer fees apply", app.ModerationHold},
+		{"wireless transfer", app.ModerationApprove},
+		{"!!!", app.ModerationApprove},
+		{"", app.ModerationApprove},
+	}
+
+	for _, tt := range tests {
+		decision, err := moderator.ReviewComment(context.Background(), domain.ItemComment{Content: tt.content})
+		if err != nil {
+			t.Fatalf("%q: ReviewComment: %v", tt.content, err)
+		}
+
+		if decision.Verdict != tt.want {
+			t.Errorf("%q: verdict = %v, want %v", tt.content, decision.Verdict, tt.want)
+		}
+		if tt.want == app.ModerationHold && decision.Reason != "word_list" {
+			t.Errorf("%q: reason = %q, want word_list", tt.content, decision.Reason)
+		}
+	}
+}
+
+func TestWordListModeratorWithoutWords(t *testing.T) {
+	decision, err := NewWordListModerator(nil).ReviewComment(context.Background(), domain.ItemComment{Content: "scam"})
+	if err != nil || decision.Verdict != app.ModerationApprove {
+		t.Errorf("decision = %+v, err = %v; want approved", decision, err)
+	}
+}
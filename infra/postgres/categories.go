@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+	"database/sql"
+)
+
+func (r *PgRepository) CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	query := `
+		INSERT INTO categories (name, description, parent_id, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING *
+	`
+
+	var created domain.Category
+	err := r.conn(ctx).GetContext(ctx, &created, query, category.Name, category.Description, category.ParentID, category.Status)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return created, nil
+}
+
+// UpdateCategory saves the name, description and status of a category. The
+// parent is only changed by MoveCategory. It returns sql.ErrNoRows when the
+// category does not exist.
+func (r *PgRepository) UpdateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	query := `
+		UPDATE categories
+		SET name = $2, description = $3, status = $4
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var updated domain.Category
+	err := r.conn(ctx).GetContext(ctx, &updated, query, category.ID, category.Name, category.Description, category.Status)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return updated, nil
+}
+
+// MoveCategory changes the parent of a category, nil making it a root. It
+// returns sql.ErrNoRows when the category does not exist. Callers hold
+// LockCategoryTree and have checked the move creates no cycle.
+func (r *PgRepository) MoveCategory(ctx context.Context, id string, parentID *string) (domain.Category, error) {
+	var moved domain.Category
+	err := r.conn(ctx).GetContext(ctx, &moved, "UPDATE categories SET parent_id = $2 WHERE id = $1 RETURNING *", id, parentID)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return moved, nil
+}
+
+func (r *PgRepository) DeleteCategory(ctx context.Context, id string) error {
+	result, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM categories WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountCategoryReferences returns the number of direct subcategories of a
+// category and of items assigned to it
+func (r *PgRepository) CountCategoryReferences(ctx context.Context, id string) (int, int, error) {
+	var counts struct {
+		Children int `db:"children"`
+		Items    int `db:"items"`
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM categories WHERE parent_id = $1) AS children,
+			(SELECT COUNT(*) FROM item_categories WHERE category_id = $1) AS items
+	`
+
+	err := r.conn(ctx).GetContext(ctx, &counts, query, id)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return counts.Children, counts.Items, nil
+}
+
+// LockCategoryTree serializes changes to the shape of the category tree until
+// the surrounding transaction ends, so concurrent moves cannot form a cycle
+// that neither of them could see
+func (r *PgRepository) LockCategoryTree(ctx context.Context) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('categories.tree'))")
+
+	return err
+}
+
+// GetCategoryPath returns the categories from the root down to the given
+// category, the category included. It is empty when the category does not exist.
+func (r *PgRepository) GetCategoryPath(ctx context.Context, id string) ([]domain.Category, error) {
+	categories := make([]domain.Category, 0)
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS depth FROM categories WHERE id = $1
+			UNION ALL
+			SELECT categories.id, categories.parent_id, ancestors.depth + 1
+			FROM categories
+			JOIN ancestors ON categories.id = ancestors.parent_id
+		) CYCLE id SET is_cycle USING visited
+		SELECT categories.* FROM ancestors
+		JOIN categories ON categories.id = ancestors.id
+		WHERE NOT ancestors.is_cycle
+		ORDER BY ancestors.depth DESC
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &categories, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// GetCategoryTree returns the categories reachable from the roots, parents
+// before their children and siblings by name. Archived categories, and with
+// them their subtrees, are left out unless includeArchived is set.
+func (r *PgRepository) GetCategoryTree(ctx context.Context, includeArchived bool) ([]domain.Category, error) {
+	categories := make([]domain.Category, 0)
+
+	query := `
+		WITH RECURSIVE category_tree AS (
+			SELECT id, 0 AS depth FROM categories
+			WHERE parent_id IS NULL AND ($1 OR status <> 'archived')
+			UNION ALL
+			SELECT categories.id, category_tree.depth + 1
+			FROM categories
+			JOIN category_tree ON categories.parent_id = category_tree.id
+			WHERE $1 OR categories.status <> 'archived'
+		)
+		SELECT categories.* FROM category_tree
+		JOIN categories ON categories.id = category_tree.id
+		ORDER BY category_tree.depth, categories.name, categories.id
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &categories, query, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
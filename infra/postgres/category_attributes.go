@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// categoryAttributeRow scans enum_values, which domain.CategoryAttribute keeps as a plain slice
+type categoryAttributeRow struct {
+	domain.CategoryAttribute
+	EnumValues pq.StringArray `db:"enum_values"`
+}
+
+func (row categoryAttributeRow) toDomain() domain.CategoryAttribute {
+	attribute := row.CategoryAttribute
+	attribute.EnumValues = append([]string{}, row.EnumValues...)
+
+	return attribute
+}
+
+// GetCategoryAttributes returns the attribute definitions of the given
+// categories, by key and then in the order they were defined
+func (r *PgRepository) GetCategoryAttributes(ctx context.Context, categoryIDs []string) ([]domain.CategoryAttribute, error) {
+	var rows []categoryAttributeRow
+
+	query := `
+		SELECT * FROM category_attributes
+		WHERE category_id = ANY($1::uuid[])
+		ORDER BY key, created_at, id
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &rows, query, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make([]domain.CategoryAttribute, len(rows))
+	for i, row := range rows {
+		attributes[i] = row.toDomain()
+	}
+
+	return attributes, nil
+}
+
+// ReplaceCategoryAttributes makes attributes the attribute schema of a
+// category. Definitions of keys that are kept are updated in place.
+func (r *PgRepository) ReplaceCategoryAttributes(ctx context.Context, categoryID string, attributes []domain.CategoryAttribute) ([]domain.CategoryAttribute, error) {
+	result := make([]domain.CategoryAttribute, 0, len(attributes))
+
+	// Joins the caller's transaction when there is one
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		keys := make([]string, len(attributes))
+		for i, attribute := range attributes {
+			keys[i] = attribute.Key
+		}
+
+		_, err := r.conn(ctx).ExecContext(ctx,
+			"DELETE FROM category_attributes WHERE category_id = $1 AND NOT (key = ANY($2))",
+			categoryID, pq.Array(keys),
+		)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO category_attributes (category_id, key, type, required, enum_values, unit)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (category_id, key) DO UPDATE SET
+				type = EXCLUDED.type,
+				required = EXCLUDED.required,
+				enum_values = EXCLUDED.enum_values,
+				unit = EXCLUDED.unit
+			RETURNING *
+		`
+
+		for _, attribute := range attributes {
+			var row categoryAttributeRow
+			err := r.conn(ctx).GetContext(ctx, &row, query,
+				categoryID, attribute.Key, attribute.Type, attribute.Required, pq.Array(attribute.EnumValues), attribute.Unit,
+			)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, row.toDomain())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
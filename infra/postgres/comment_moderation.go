@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+)
+
+// UpdateCommentStatus stores the status and moderation details of a comment.
+// It returns sql.ErrNoRows when the comment no longer has the previous status,
+// i.e. someone else moderated it first.
+func (r *PgRepository) UpdateCommentStatus(ctx context.Context, comment domain.ItemComment, previous domain.CommentStatus) (domain.ItemComment, error) {
+	query := `
+		UPDATE item_comments SET
+			status = $3,
+			moderated_by = $4,
+			moderated_at = $5,
+			moderation_reason = $6,
+			deleted_at = $7
+		WHERE id = $1 AND status = $2
+		RETURNING *
+	`
+
+	var updated domain.ItemComment
+	err := r.conn(ctx).GetContext(ctx, &updated, query,
+		comment.ID, previous, comment.Status, comment.ModeratedBy, comment.ModeratedAt, comment.ModerationReason, comment.DeletedAt,
+	)
+	if err != nil {
+		return domain.ItemComment{}, err
+	}
+
+	return updated, nil
+}
+
+// CreateCommentReport stores a report unless the reporter already reported
+// the comment, and returns the number of reports of the comment
+func (r *PgRepository) CreateCommentReport(ctx context.Context, report domain.ItemCommentReport) (bool, int, error) {
+	var counts struct {
+		Created int `db:"created"`
+		Reports int `db:"reports"`
+	}
+
+	// The count cannot see the row inserted by the same statement, hence the sum
+	query := `
+		WITH inserted AS (
+			INSERT INTO item_comment_reports (comment_id, reporter_id, reason, details)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (comment_id, reporter_id) DO NOTHING
+			RETURNING id
+		)
+		SELECT
+			(SELECT COUNT(*) FROM inserted) AS created,
+			(SELECT COUNT(*) FROM inserted) + (SELECT COUNT(*) FROM item_comment_reports WHERE comment_id = $1) AS reports
+	`
+
+	err := r.conn(ctx).GetContext(ctx, &counts, query, report.CommentID, report.ReporterID, report.Reason, report.Details)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return counts.Created > 0, counts.Reports, nil
+}
+
+// GetCommentsByStatus returns the comments with a status, oldest first, with
+// their number of reports
+func (r *PgRepository) GetCommentsByStatus(ctx context.Context, status domain.CommentStatus, page, pageSize int) ([]app.ReviewedComment, error) {
+	comments := make([]app.ReviewedComment, 0)
+
+	offset := (page - 1) * pageSize
+
+	query := `
+		SELECT
+			item_comments.*,
+			(SELECT COUNT(*) FROM item_comment_reports WHERE comment_id = item_comments.id) AS report_count
+		FROM item_comments
+		WHERE status = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &comments, query, status, pageSize, offset)
+	if err != nil {
+		return comments, err
+	}
+
+	return comments, nil
+}
+
+func (r *PgRepository) CountCommentsByStatus(ctx context.Context, status domain.CommentStatus) (int, error) {
+	var count int
+
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_comments WHERE status = $1", status)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// Roots are listed newest first like the flat listing, replies oldest first
+var (
+	rootCommentKeyset = commentKeyset
+	replyKeyset       = keyset{column: "created_at", idColumn: "id", cast: "timestamptz"}
+)
+
+func (r *PgRepository) GetRootCommentsByItemID(ctx context.Context, itemID string, page, pageSize int) ([]domain.ItemComment, error) {
+	comments := make([]domain.ItemComment, 0)
+
+	offset := (page - 1) * pageSize
+
+	query := `
+		SELECT * FROM item_comments
+		WHERE item_id = $1 AND parent_id IS NULL
+		ORDER BY ` + rootCommentKeyset.orderBy(false) + `
+		LIMIT $2 OFFSET $3
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &comments, query, itemID, pageSize, offset)
+	if err != nil {
+		return comments, err
+	}
+
+	return comments, nil
+}
+
+// GetRootCommentsPage returns the page of top-level comments of an item
+// following the cursor, and whether more exist beyond it
+func (r *PgRepository) GetRootCommentsPage(ctx context.Context, itemID string, page app.PageRequest) ([]domain.ItemComment, bool, error) {
+	comments := make([]domain.ItemComment, 0)
+	q := &queryArgs{}
+
+	query := `SELECT * FROM item_comments WHERE item_id = ` + q.bind(itemID) + ` AND parent_id IS NULL`
+	if page.Cursor != nil {
+		query += ` AND ` + rootCommentKeyset.after(page.Cursor, q.bind)
+	}
+	query += ` ORDER BY ` + rootCommentKeyset.orderBy(page.Backward()) + ` LIMIT ` + q.bind(page.Limit+1)
+
+	err := r.conn(ctx).SelectContext(ctx, &comments, query, q.args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments, more := keysetPage(comments, page)
+
+	return comments, more, nil
+}
+
+func (r *PgRepository) CountRootComments(ctx context.Context, itemID string) (int, error) {
+	var count int
+
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_comments WHERE item_id = $1 AND parent_id IS NULL", itemID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// commentReplyRow is a reply in the tree of a root comment
+type commentReplyRow struct {
+	app.CommentReply
+	RootID       string `db:"root_id"`
+	Position     int    `db:"position"`
+	RootReplies  int    `db:"root_replies"`
+	TotalReplies int    `db:"total_replies"`
+}
+
+// GetCommentReplies returns up to limit replies from the reply tree of each
+// root comment, oldest first, along with the reply counts of the roots. With a
+// limit of 0 only the counts are returned. Roots without replies are missing
+// from the result.
+func (r *PgRepository) GetCommentReplies(ctx context.Context, rootIDs []string, limit int) (map[string]app.CommentReplies, error) {
+	var rows []commentReplyRow
+
+	query := `
+		WITH RECURSIVE thread AS (
+			SELECT item_comments.*, item_comments.parent_id AS root_id
+			FROM item_comments
+			WHERE parent_id = ANY($1::uuid[])
+
+			UNION ALL
+
+			SELECT item_comments.*, thread.root_id
+			FROM item_comments
+			JOIN thread ON item_comments.parent_id = thread.id
+		), ranked AS (
+			SELECT
+				thread.*,
+				ROW_NUMBER() OVER (PARTITION BY root_id ORDER BY created_at, id) AS position,
+				COUNT(*) FILTER (WHERE parent_id = root_id) OVER (PARTITION BY root_id) AS root_replies,
+				COUNT(*) OVER (PARTITION BY root_id) AS total_replies
+			FROM thread
+		)
+		SELECT
+			ranked.*,
+			(SELECT COUNT(*) FROM item_comments replies WHERE replies.parent_id = ranked.id) AS reply_count
+		FROM ranked
+		WHERE position <= GREATEST($2, 1) -- One row per root carries its counts
+		ORDER BY root_id, position
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &rows, query, pq.Array(rootIDs), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make(map[string]app.CommentReplies, len(rootIDs))
+	for _, row := range rows {
+		root := replies[row.RootID]
+		root.ReplyCount = row.RootReplies
+		root.TotalReplies = row.TotalReplies
+		if row.Position <= limit {
+			root.Replies = append(root.Replies, row.CommentReply)
+		}
+		replies[row.RootID] = root
+	}
+
+	return replies, nil
+}
+
+// GetCommentRepliesPage returns the page of direct replies to a comment
+// following the cursor, oldest first, and whether more exist beyond it
+func (r *PgRepository) GetCommentRepliesPage(ctx context.Context, commentID string, page app.PageRequest) ([]app.CommentReply, bool, error) {
+	replies := make([]app.CommentReply, 0)
+	q := &queryArgs{}
+
+	query := `
+		SELECT
+			item_comments.*,
+			(SELECT COUNT(*) FROM item_comments replies WHERE replies.parent_id = item_comments.id) AS reply_count
+		FROM item_comments
+		WHERE parent_id = ` + q.bind(commentID)
+	if page.Cursor != nil {
+		query += ` AND ` + replyKeyset.after(page.Cursor, q.bind)
+	}
+	query += ` ORDER BY ` + replyKeyset.orderBy(page.Backward()) + ` LIMIT ` + q.bind(page.Limit+1)
+
+	err := r.conn(ctx).SelectContext(ctx, &replies, query, q.args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	replies, more := keysetPage(replies, page)
+
+	return replies, more, nil
+}
+
+func (r *PgRepository) CountCommentReplies(ctx context.Context, commentID string) (int, error) {
+	var count int
+
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_comments WHERE parent_id = $1", commentID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// GetCategoriesByIDs returns the categories with the given ids that exist
+func (r *PgRepository) GetCategoriesByIDs(ctx context.Context, ids []string) ([]domain.Category, error) {
+	categories := make([]domain.Category, 0)
+
+	err := r.conn(ctx).SelectContext(ctx, &categories, "SELECT * FROM categories WHERE id = ANY($1::uuid[])", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// AddItemCategories assigns categories to an item and returns the ids of the
+// ones that were not assigned yet
+func (r *PgRepository) AddItemCategories(ctx context.Context, itemID string, categoryIDs []string) ([]string, error) {
+	added := make([]string, 0)
+
+	query := `
+		INSERT INTO item_categories (item_id, category_id)
+		SELECT $1, category_id FROM unnest($2::uuid[]) AS category_id
+		ON CONFLICT (item_id, category_id) DO NOTHING
+		RETURNING category_id
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &added, query, itemID, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// RemoveItemCategories unassigns categories from an item and returns the ids of
+// the ones that were assigned
+func (r *PgRepository) RemoveItemCategories(ctx context.Context, itemID string, categoryIDs []string) ([]string, error) {
+	removed := make([]string, 0)
+
+	query := `
+		DELETE FROM item_categories
+		WHERE item_id = $1 AND category_id = ANY($2::uuid[])
+		RETURNING category_id
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &removed, query, itemID, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// ReplaceItemCategories makes categoryIDs the only categories of an item and
+// returns the ids that were added and removed
+func (r *PgRepository) ReplaceItemCategories(ctx context.Context, itemID string, categoryIDs []string) ([]string, []string, error) {
+	removed := make([]string, 0)
+
+	query := `
+		DELETE FROM item_categories
+		WHERE item_id = $1 AND NOT (category_id = ANY($2::uuid[]))
+		RETURNING category_id
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &removed, query, itemID, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	added, err := r.AddItemCategories(ctx, itemID, categoryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return added, removed, nil
+}
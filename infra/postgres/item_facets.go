@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// itemFacetRow is one count of the facet query. facet tells which of the
+// other columns are set.
+type itemFacetRow struct {
+	Facet  string         `db:"facet"` // total, status, price, category or attribute
+	Key    sql.NullString `db:"key"`   // Currency of price rows, key of attribute rows
+	Value  sql.NullString `db:"value"` // Status, category id or attribute value
+	Label  sql.NullString `db:"label"` // Category name
+	Bucket sql.NullInt64  `db:"bucket"`
+	Count  int            `db:"count"`
+}
+
+// GetItemFacets counts the items matching the filter per status, price bucket,
+// category and attribute value in a single query. The filtered items are
+// computed once; statuses, price buckets and the total share one scan through
+// grouping sets.
+func (r *PgRepository) GetItemFacets(ctx context.Context, filter app.ItemFilter, options app.ItemFacetOptions) (app.ItemFacets, error) {
+	q := newItemQuery(filter)
+
+	bounds := make([]string, len(options.PriceBuckets))
+	for i, bound := range options.PriceBuckets {
+		bounds[i] = bound.String()
+	}
+	boundsArg := q.bind(pq.Array(bounds))
+	limitArg := q.bind(options.AttributeValueLimit)
+
+	query := `
+		WITH filtered AS (
+			SELECT
+				items.id,
+				items.status,
+				items.currency_code,
+				width_bucket(items.current_price, ` + boundsArg + `::numeric[]) AS price_bucket
+			FROM items
+			` + q.whereClause() + `
+		)
+		SELECT
+			CASE
+				WHEN GROUPING(status) = 0 THEN 'status'
+				WHEN GROUPING(currency_code) = 0 THEN 'price'
+				ELSE 'total'
+			END AS facet,
+			currency_code AS key,
+			status AS value,
+			NULL AS label,
+			price_bucket AS bucket,
+			COUNT(*) AS count
+		FROM filtered
+		GROUP BY GROUPING SETS ((status), (currency_code, price_bucket), ())
+
+		UNION ALL
+
+		SELECT 'category', NULL, categories.id::text, categories.name, NULL, COUNT(*)
+		FROM filtered
+		JOIN item_categories ON item_categories.item_id = filtered.id
+		JOIN categories ON categories.id = item_categories.category_id
+		GROUP BY categories.id, categories.name
+
+		UNION ALL
+
+		SELECT 'attribute', key, value, NULL, NULL, count
+		FROM (
+			SELECT
+				item_attributes.key,
+				item_attributes.value,
+				COUNT(DISTINCT filtered.id) AS count,
+				ROW_NUMBER() OVER (
+					PARTITION BY item_attributes.key
+					ORDER BY COUNT(DISTINCT filtered.id) DESC, item_attributes.value
+				) AS position
+			FROM filtered
+			JOIN item_attributes ON item_attributes.item_id = filtered.id
+			GROUP BY item_attributes.key, item_attributes.value
+		) attribute_counts
+		WHERE position <= ` + limitArg
+
+	var rows []itemFacetRow
+	err := r.conn(ctx).SelectContext(ctx, &rows, query, q.args...)
+	if err != nil {
+		return app.ItemFacets{}, err
+	}
+
+	return buildItemFacets(rows, options.PriceBuckets), nil
+}
+
+func buildItemFacets(rows []itemFacetRow, bounds []decimal.Decimal) app.ItemFacets {
+	facets := app.ItemFacets{
+		Statuses:     []app.StatusFacet{},
+		Categories:   []app.CategoryFacet{},
+		Attributes:   []app.AttributeFacet{},
+		PriceBuckets: []app.PriceBucketFacet{},
+	}
+
+	type priceBucket struct {
+		facet  app.PriceBucketFacet
+		bucket int64
+	}
+	var priceBuckets []priceBucket
+	attributes := make(map[string]int)
+
+	for _, row := range rows {
+		switch row.Facet {
+		case "total":
+			facets.Total = row.Count
+
+		case "status":
+			facets.Statuses = append(facets.Statuses, app.StatusFacet{
+				Status: domain.ItemStatus(row.Value.String),
+				Count:  row.Count,
+			})
+
+		case "price":
+			// width_bucket returns 0 below the first boundary and len(bounds) from the last one on
+			bucket := row.Bucket.Int64
+			facet := app.PriceBucketFacet{CurrencyCode: row.Key.String, Count: row.Count}
+			if bucket > 0 {
+				facet.Min = &bounds[bucket-1]
+			}
+			if bucket < int64(len(bounds)) {
+				facet.Max = &bounds[bucket]
+			}
+			priceBuckets = append(priceBuckets, priceBucket{facet: facet, bucket: bucket})
+
+		case "category":
+			facets.Categories = append(facets.Categories, app.CategoryFacet{
+				ID:    row.Value.String,
+				Name:  row.Label.String,
+				Count: row.Count,
+			})
+
+		case "attribute":
+			i, ok := attributes[row.Key.String]
+			if !ok {
+				i = len(facets.Attributes)
+				attributes[row.Key.String] = i
+				facets.Attributes = append(facets.Attributes, app.AttributeFacet{Key: row.Key.String})
+			}
+			facets.Attributes[i].Values = append(facets.Attributes[i].Values, app.AttributeValueFacet{
+				Value: row.Value.String,
+				Count: row.Count,
+			})
+		}
+	}
+
+	// UNION ALL does not keep the order of its parts: most frequent first, ties by name
+	sort.Slice(facets.Statuses, func(i, j int) bool {
+		a, b := facets.Statuses[i], facets.Statuses[j]
+		return a.Count > b.Count || a.Count == b.Count && a.Status < b.Status
+	})
+	sort.Slice(facets.Categories, func(i, j int) bool {
+		a, b := facets.Categories[i], facets.Categories[j]
+		return a.Count > b.Count || a.Count == b.Count && a.Name < b.Name
+	})
+	sort.Slice(facets.Attributes, func(i, j int) bool {
+		return facets.Attributes[i].Key < facets.Attributes[j].Key
+	})
+	for _, attribute := range facets.Attributes {
+		sort.Slice(attribute.Values, func(i, j int) bool {
+			a, b := attribute.Values[i], attribute.Values[j]
+			return a.Count > b.Count || a.Count == b.Count && a.Value < b.Value
+		})
+	}
+
+	sort.Slice(priceBuckets, func(i, j int) bool {
+		if priceBuckets[i].facet.CurrencyCode != priceBuckets[j].facet.CurrencyCode {
+			return priceBuckets[i].facet.CurrencyCode < priceBuckets[j].facet.CurrencyCode
+		}
+		return priceBuckets[i].bucket < priceBuckets[j].bucket
+	})
+	for _, bucket := range priceBuckets {
+		facets.PriceBuckets = append(facets.PriceBuckets, bucket.facet)
+	}
+
+	return facets
+}
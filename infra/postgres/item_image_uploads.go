@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+	"time"
+)
+
+func (r *PgRepository) CreateImageUpload(ctx context.Context, upload domain.ItemImageUpload) (domain.ItemImageUpload, error) {
+	query := `
+		INSERT INTO item_image_uploads (id, item_id, object_key, content_type, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING *
+	`
+
+	var created domain.ItemImageUpload
+	err := r.conn(ctx).GetContext(ctx, &created, query, upload.ID, upload.ItemID, upload.ObjectKey, upload.ContentType, upload.ExpiresAt)
+	if err != nil {
+		return domain.ItemImageUpload{}, err
+	}
+
+	return created, nil
+}
+
+func (r *PgRepository) GetImageUpload(ctx context.Context, itemID string, uploadID string) (domain.ItemImageUpload, error) {
+	var upload domain.ItemImageUpload
+
+	err := r.conn(ctx).GetContext(ctx, &upload, "SELECT * FROM item_image_uploads WHERE id = $1 AND item_id = $2", uploadID, itemID)
+	if err != nil {
+		return domain.ItemImageUpload{}, err
+	}
+
+	return upload, nil
+}
+
+// CountPendingImageUploads counts the uploads of an item whose URL is still
+// valid at now
+func (r *PgRepository) CountPendingImageUploads(ctx context.Context, itemID string, now time.Time) (int, error) {
+	var count int
+
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_image_uploads WHERE item_id = $1 AND expires_at > $2", itemID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CompleteImageUpload turns an upload into an image with the same id, after
+// the last image of the item. The first image of an item becomes its primary
+// image. Returns sql.ErrNoRows when the upload no longer exists. Callers hold
+// LockItemImages.
+func (r *PgRepository) CompleteImageUpload(ctx context.Context, upload domain.ItemImageUpload, imageURL string) (domain.ItemImage, error) {
+	query := `
+		WITH completed AS (
+			DELETE FROM item_image_uploads
+			WHERE id = $1 AND item_id = $2
+			RETURNING id, item_id
+		)
+		INSERT INTO item_images (id, item_id, url, display_order, is_primary)
+		SELECT
+			completed.id,
+			completed.item_id,
+			$3,
+			(SELECT COALESCE(MAX(display_order) + 1, 0) FROM item_images WHERE item_id = $2),
+			NOT EXISTS (SELECT 1 FROM item_images WHERE item_id = $2 AND is_primary)
+		FROM completed
+		RETURNING *
+	`
+
+	var image domain.ItemImage
+	err := r.conn(ctx).GetContext(ctx, &image, query, upload.ID, upload.ItemID, imageURL)
+	if err != nil {
+		return domain.ItemImage{}, err
+	}
+
+	return image, nil
+}
+
+func (r *PgRepository) DeleteImageUpload(ctx context.Context, uploadID string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM item_image_uploads WHERE id = $1", uploadID)
+
+	return err
+}
+
+// ClaimExpiredImageUploads locks up to limit uploads that expired before the
+// given time, skipping rows locked by another worker. Must run within a
+// transaction.
+func (r *PgRepository) ClaimExpiredImageUploads(ctx context.Context, before time.Time, limit int) ([]domain.ItemImageUpload, error) {
+	uploads := make([]domain.ItemImageUpload, 0)
+
+	query := `
+		SELECT * FROM item_image_uploads
+		WHERE expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+
+	err := r.conn(ctx).SelectContext(ctx, &uploads, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// GetItemImageVariants returns the variants of the given images, ordered by
+// image, size and format
+func (r *PgRepository) GetItemImageVariants(ctx context.Context, imageIDs []string) ([]domain.ItemImageVariant, error) {
+	variants := make([]domain.ItemImageVariant, 0)
+	if len(imageIDs) == 0 {
+		return variants, nil
+	}
+
+	query := `
+		SELECT * FROM item_image_variants
+		WHERE image_id = ANY($1::uuid[])
+		ORDER BY image_id, size, format
+	`
+
+	err := r.conn(ctx).SelectContext(ctx, &variants, query, pq.Array(imageIDs))
+	if err != nil {
+		return variants, err
+	}
+
+	return variants, nil
+}
+
+// SaveItemImageVariants inserts the variants, replacing those already stored
+// for the same image, size and format
+func (r *PgRepository) SaveItemImageVariants(ctx context.Context, variants []domain.ItemImageVariant) ([]domain.ItemImageVariant, error) {
+	if len(variants) == 0 {
+		return []domain.ItemImageVariant{}, nil
+	}
+
+	values := make([]any, 0)
+	placeholders := make([]string, 0)
+
+	paramIndex := 1
+	for _, variant := range variants {
+		values = append(values, variant.ImageID, variant.Size, variant.Format, variant.ImageURL, variant.Width, variant.Height, variant.Bytes)
+		placeholders = append(placeholders, fmt.Sprintf(
+			"($%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			paramIndex, paramIndex+1, paramIndex+2, paramIndex+3, paramIndex+4, paramIndex+5, paramIndex+6,
+		))
+		paramIndex += 7
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO item_image_variants (image_id, size, format, url, width, height, bytes)
+		VALUES %s
+		ON CONFLICT (image_id, size, format) DO UPDATE SET
+			url = EXCLUDED.url,
+			width = EXCLUDED.width,
+			height = EXCLUDED.height,
+			bytes = EXCLUDED.bytes
+		RETURNING *
+	`, strings.Join(placeholders, ", "))
+
+	result := make([]domain.ItemImageVariant, 0)
+	err := r.conn(ctx).SelectContext(ctx, &result, query, values...)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
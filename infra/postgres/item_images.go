@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"auction/domain"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// GetAllItemImages returns every image of an item in display order
+func (r *PgRepository) GetAllItemImages(ctx context.Context, itemID string) ([]domain.ItemImage, error) {
+	images := make([]domain.ItemImage, 0)
+
+	err := r.conn(ctx).SelectContext(ctx, &images, "SELECT * FROM item_images WHERE item_id = $1 ORDER BY display_order, id", itemID)
+	if err != nil {
+		return images, err
+	}
+
+	return images, nil
+}
+
+// GetPrimaryItemImage returns the cover image of an item, sql.ErrNoRows when
+// the item has no images
+func (r *PgRepository) GetPrimaryItemImage(ctx context.Context, itemID string) (domain.ItemImage, error) {
+	var image domain.ItemImage
+
+	err := r.conn(ctx).GetContext(ctx, &image, "SELECT * FROM item_images WHERE item_id = $1 AND is_primary", itemID)
+	if err != nil {
+		return domain.ItemImage{}, err
+	}
+
+	return image, nil
+}
+
+// ReorderItemImages gives the images of an item the display order of
+// imageIDs, which lists every image of the item exactly once
+func (r *PgRepository) ReorderItemImages(ctx context.Context, itemID string, imageIDs []string) error {
+	query := `
+		UPDATE item_images
+		SET display_order = ordered.position - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(id, position)
+		WHERE item_images.id = ordered.id
+		AND item_images.item_id = $1
+		AND item_images.display_order <> ordered.position - 1
+	`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, itemID, pq.Array(imageIDs))
+
+	return err
+}
+
+// SetPrimaryItemImage makes an image the cover image of its item
+func (r *PgRepository) SetPrimaryItemImage(ctx context.Context, itemID string, imageID string) error {
+	// Clear the current primary image first, its index is not deferrable
+	_, err := r.conn(ctx).ExecContext(ctx, "UPDATE item_images SET is_primary = FALSE WHERE item_id = $1 AND is_primary AND id <> $2", itemID, imageID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx, "UPDATE item_images SET is_primary = TRUE WHERE item_id = $1 AND id = $2 AND NOT is_primary", itemID, imageID)
+
+	return err
+}
+
+// LockItemImages serializes changes to the images of an item until the
+// surrounding transaction ends, so display orders and the image limit are
+// checked against current rows
+func (r *PgRepository) LockItemImages(ctx context.Context, itemID string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('item_images:' || $1::text))", itemID)
+
+	return err
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"auction/app"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// itemKeysets whitelists the sort columns of each sort order. Sort values from
+// requests are never interpolated into SQL directly. The trailing id makes the
+// order deterministic for pagination.
+var itemKeysets = map[app.ItemSort]keyset{
+	app.ItemSortNewest:     {column: "items.created_at", idColumn: "items.id", cast: "timestamptz", descending: true},
+	app.ItemSortEndingSoon: {column: "items.end_date", idColumn: "items.id", cast: "timestamptz"},
+	app.ItemSortPriceAsc:   {column: "items.current_price", idColumn: "items.id", cast: "numeric"},
+	app.ItemSortPriceDesc:  {column: "items.current_price", idColumn: "items.id", cast: "numeric", descending: true},
+	app.ItemSortMostBids:   {column: "items.bid_count", idColumn: "items.id", cast: "int", descending: true},
+}
+
+// itemQuery builds the WHERE clause of an item listing. Filter values are always
+// bound as positional arguments; only fixed SQL fragments are concatenated.
+type itemQuery struct {
+	queryArgs
+	conditions []string
+}
+
+func newItemQuery(filter app.ItemFilter) *itemQuery {
+	q := &itemQuery{}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		q.where("items.status = ANY(" + q.bind(pq.Array(statuses)) + ")")
+	}
+
+	if filter.CategoryID != nil {
+		q.where(`items.id IN (
+			SELECT item_categories.item_id FROM item_categories
+			WHERE item_categories.category_id IN (
+				WITH RECURSIVE category_tree AS (
+					SELECT id FROM categories WHERE id = ` + q.bind(*filter.CategoryID) + `
+					UNION
+					SELECT categories.id FROM categories
+					JOIN category_tree ON categories.parent_id = category_tree.id
+				)
+				SELECT id FROM category_tree
+			)
+		)`)
+	}
+
+	if filter.SellerID != nil {
+		q.where("items.seller_id = " + q.bind(*filter.SellerID))
+	}
+	if filter.MinPrice != nil {
+		q.where("items.current_price >= " + q.bind(*filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		q.where("items.current_price <= " + q.bind(*filter.MaxPrice))
+	}
+	if filter.CurrencyCode != nil {
+		q.where("items.currency_code = " + q.bind(strings.ToUpper(*filter.CurrencyCode)))
+	}
+	if filter.EndingBefore != nil {
+		q.where("items.end_date < " + q.bind(*filter.EndingBefore))
+	}
+	if filter.EndingAfter != nil {
+		q.where("items.end_date > " + q.bind(*filter.EndingAfter))
+	}
+
+	// Sorted so the same filter always produces the same SQL
+	keys := make([]string, 0, len(filter.Attributes))
+	for key := range filter.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		q.where(`EXISTS (
+			SELECT 1 FROM item_attributes
+			WHERE item_attributes.item_id = items.id
+			AND item_attributes.key = ` + q.bind(key) + `
+			AND item_attributes.value = ` + q.bind(filter.Attributes[key]) + `
+		)`)
+	}
+
+	return q
+}
+
+func (q *itemQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// whereClause returns the WHERE clause, or an empty string without conditions
+func (q *itemQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+func (q *itemQuery) keyset(itemSort app.ItemSort) keyset {
+	if keyset, ok := itemKeysets[itemSort]; ok {
+		return keyset
+	}
+
+	return itemKeysets[app.ItemSortNewest]
+}
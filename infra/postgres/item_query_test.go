@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewItemQuery(t *testing.T) {
+	category := "0b7e4f5c-8d3a-4a7e-9c1f-2e6d5b4a3c21"
+	seller := "seller"
+	currency := "eur"
+	minPrice := decimal.NewFromInt(10)
+	maxPrice := decimal.NewFromInt(100)
+	before := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	q := newItemQuery(app.ItemFilter{
+		Statuses:     []domain.ItemStatus{domain.ItemStatusActive, domain.ItemStatusEnded},
+		CategoryID:   &category,
+		SellerID:     &seller,
+		MinPrice:     &minPrice,
+		MaxPrice:     &maxPrice,
+		CurrencyCode: &currency,
+		EndingBefore: &before,
+		EndingAfter:  &after,
+		Attributes:   map[string]string{"mount": "M", "brand": "Leica"},
+	})
+
+	wantArgs := []any{
+		pq.Array([]string{"active", "ended"}), category, seller, minPrice, maxPrice, "EUR", before, after,
+		"brand", "Leica", "mount", "M",
+	}
+	if !reflect.DeepEqual(q.args, wantArgs) {
+		t.Errorf("args = %v, want %v", q.args, wantArgs)
+	}
+
+	wantConditions := []string{
+		"items.status = ANY($1)",
+		"category_tree",
+		"items.seller_id = $3",
+		"items.current_price >= $4",
+		"items.current_price <= $5",
+		"items.currency_code = $6",
+		"items.end_date < $7",
+		"items.end_date > $8",
+		"item_attributes.key = $9",
+		"item_attributes.key = $11",
+	}
+	if len(q.conditions) != len(wantConditions) {
+		t.Fatalf("got %d conditions, want %d", len(q.conditions), len(wantConditions))
+	}
+	for i, want := range wantConditions {
+		if !strings.Contains(q.conditions[i], want) {
+			t.Errorf("condition %d = %q, want it to contain %q", i, q.conditions[i], want)
+		}
+	}
+
+	where := q.whereClause()
+	if !strings.HasPrefix(where, "WHERE items.status") || strings.Count(where, " AND items.") < 6 {
+		t.Errorf("where clause = %q", where)
+	}
+}
+
+func TestNewItemQueryWithoutFilters(t *testing.T) {
+	q := newItemQuery(app.ItemFilter{})
+
+	if len(q.args) != 0 || q.whereClause() != "" {
+		t.Errorf("args = %v, where = %q; want none", q.args, q.whereClause())
+	}
+}
+
+func TestItemQueryKeyset(t *testing.T) {
+	q := newItemQuery(app.ItemFilter{})
+
+	if got := q.keyset(app.ItemSortPriceAsc); got != itemKeysets[app.ItemSortPriceAsc] {
+		t.Errorf("price_asc keyset = %+v", got)
+	}
+	if got := q.keyset("DROP TABLE items"); got != itemKeysets[app.ItemSortNewest] {
+		t.Errorf("unknown sort keyset = %+v, want newest", got)
+	}
+}
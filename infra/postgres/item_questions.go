@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"auction/app"
+	"context"
+)
+
+// sellerQuestionsWhere selects the visible questions matching the filter
+func sellerQuestionsWhere(filter app.SellerQuestionFilter, q *queryArgs) string {
+	where := `
+		WHERE items.seller_id = ` + q.bind(filter.SellerID) + `
+		AND questions.kind = 'question'
+		AND questions.status = 'visible'
+	`
+
+	if filter.ItemID != "" {
+		where += ` AND questions.item_id = ` + q.bind(filter.ItemID)
+	}
+
+	if filter.Unanswered {
+		where += `
+			AND NOT EXISTS (
+				SELECT 1 FROM item_comments answers
+				WHERE answers.parent_id = questions.id
+				AND answers.kind = 'answer'
+				AND answers.status = 'visible'
+			)
+		`
+	}
+
+	return where
+}
+
+// GetSellerQuestions returns the questions asked on the items of a seller,
+// oldest first so the longest waiting ones come first
+func (r *PgRepository) GetSellerQuestions(ctx context.Context, filter app.SellerQuestionFilter, page, pageSize int) ([]app.SellerQuestion, error) {
+	questions := make([]app.SellerQuestion, 0)
+	q := &queryArgs{}
+
+	query := `
+		SELECT
+			questions.*,
+			items.name AS item_name,
+			(
+				SELECT COUNT(*) FROM item_comments answers
+				WHERE answers.parent_id = questions.id
+				AND answers.kind = 'answer'
+				AND answers.status = 'visible'
+			) AS answer_count
+		FROM item_comments questions
+		JOIN items ON items.id = questions.item_id
+	` + sellerQuestionsWhere(filter, q) + `
+		ORDER BY questions.created_at, questions.id
+		LIMIT ` + q.bind(pageSize) + ` OFFSET ` + q.bind((page-1)*pageSize)
+
+	err := r.conn(ctx).SelectContext(ctx, &questions, query, q.args...)
+	if err != nil {
+		return questions, err
+	}
+
+	return questions, nil
+}
+
+func (r *PgRepository) CountSellerQuestions(ctx context.Context, filter app.SellerQuestionFilter) (int, error) {
+	var count int
+	q := &queryArgs{}
+
+	query := `
+		SELECT COUNT(*)
+		FROM item_comments questions
+		JOIN items ON items.id = questions.item_id
+	` + sellerQuestionsWhere(filter, q)
+
+	err := r.conn(ctx).GetContext(ctx, &count, query, q.args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
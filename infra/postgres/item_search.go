@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// documentQuery is the query parsed in the language of the search document
+const documentQuery = "websearch_to_tsquery(item_search_documents.language, $1)"
+
+// searchQuery returns the conditions items matching search meet and their
+// arguments. The query parsed with each language, ORed, lets the GIN index
+// narrow down documents before each is matched with the query parsed in its
+// own language.
+func searchQuery(search app.ItemSearch) (string, []any) {
+	args := []any{search.Query}
+	parts := make([]string, 0, len(search.Languages))
+
+	for _, language := range search.Languages {
+		args = append(args, language)
+		parts = append(parts, fmt.Sprintf("websearch_to_tsquery($%d::regconfig, $1)", len(args)))
+	}
+	tsQuery := "(" + strings.Join(parts, " || ") + ")"
+
+	statuses := make([]string, len(search.Statuses))
+	for i, status := range search.Statuses {
+		statuses[i] = string(status)
+	}
+	args = append(args, pq.Array(search.Languages), pq.Array(statuses))
+
+	conditions := []string{
+		"item_search_documents.document @@ " + tsQuery,
+		"item_search_documents.document @@ " + documentQuery,
+		fmt.Sprintf("item_search_documents.language = ANY($%d::regconfig[])", len(args)-1),
+		fmt.Sprintf("items.status = ANY($%d)", len(args)),
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// SearchItems returns items whose search document matches the query, best ranked first.
+// Matches in the name weigh more than in the description, which weigh more than in attributes.
+func (r *PgRepository) SearchItems(ctx context.Context, search app.ItemSearch, limit, offset int) ([]app.ItemSearchResult, error) {
+	// Temporary struct to hold the query result with JSON categories
+	type searchRow struct {
+		domain.Item
+		CategoriesJSON sql.NullString `db:"categories"`
+		Rank           float64        `db:"rank"`
+		NameHighlight  string         `db:"name_highlight"`
+		Snippet        string         `db:"snippet"`
+	}
+
+	conditions, args := searchQuery(search)
+	args = append(args, limit, offset)
+
+	query := `
+		SELECT
+			items.*,
+			COALESCE((
+				SELECT json_agg(
+					json_build_object(
+						'id', categories.id,
+						'name', categories.name,
+						'description', categories.description,
+						'parent_id', categories.parent_id,
+						'status', categories.status,
+						'created_at', categories.created_at,
+						'updated_at', categories.updated_at
+					)
+				)
+				FROM item_categories
+				JOIN categories ON item_categories.category_id = categories.id
+				WHERE item_categories.item_id = items.id
+			), '[]') as categories,
+			ts_rank(item_search_documents.document, ` + documentQuery + `) AS rank,
+			ts_headline(item_search_documents.language, items.name, ` + documentQuery + `,
+				'HighlightAll=true') AS name_highlight,
+			ts_headline(item_search_documents.language, COALESCE(items.description, ''), ` + documentQuery + `,
+				'MaxFragments=2, MinWords=5, MaxWords=20') AS snippet
+		FROM items
+		JOIN item_search_documents ON item_search_documents.item_id = items.id
+		WHERE ` + conditions + `
+		ORDER BY rank DESC, items.id DESC
+		LIMIT ` + fmt.Sprintf("$%d OFFSET $%d", len(args)-1, len(args))
+
+	var rows []searchRow
+	err := r.conn(ctx).SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]app.ItemSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = app.ItemSearchResult{
+			Item:          row.Item,
+			Rank:          row.Rank,
+			NameHighlight: row.NameHighlight,
+			Snippet:       row.Snippet,
+		}
+
+		// Unmarshal categories JSON if present
+		if row.CategoriesJSON.Valid && row.CategoriesJSON.String != "[]" {
+			if err := json.Unmarshal([]byte(row.CategoriesJSON.String), &results[i].Item.Categories); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+			}
+		} else {
+			results[i].Item.Categories = []domain.Category{}
+		}
+	}
+
+	return results, nil
+}
+
+func (r *PgRepository) CountSearchItems(ctx context.Context, search app.ItemSearch) (int, error) {
+	var count int
+	conditions, args := searchQuery(search)
+
+	query := `
+		SELECT COUNT(*) FROM item_search_documents
+		JOIN items ON items.id = item_search_documents.item_id
+		WHERE ` + conditions
+
+	err := r.conn(ctx).GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
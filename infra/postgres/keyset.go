@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"auction/app"
+	"fmt"
+	"slices"
+)
+
+// keyset is the sort order of a cursor-paginated listing: a sort column
+// followed by the id as tie breaker, both in the same direction.
+type keyset struct {
+	column     string
+	idColumn   string
+	cast       string // SQL type the cursor value is cast to
+	descending bool
+}
+
+var (
+	categoryKeyset = keyset{column: "created_at", idColumn: "id", cast: "timestamptz", descending: true}
+	commentKeyset  = keyset{column: "created_at", idColumn: "id", cast: "timestamptz", descending: true}
+	imageKeyset    = keyset{column: "display_order", idColumn: "id", cast: "int"}
+)
+
+// orderBy returns the ORDER BY clause, reversed when reading backward
+func (k keyset) orderBy(backward bool) string {
+	direction := "ASC"
+	if k.descending != backward {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, %s %s", k.column, direction, k.idColumn, direction)
+}
+
+// after returns the condition selecting the rows following the cursor in the
+// direction it is read
+func (k keyset) after(cursor *app.Cursor, bind func(value any) string) string {
+	operator := ">"
+	if k.descending != cursor.Backward {
+		operator = "<"
+	}
+
+	return fmt.Sprintf("(%s, %s) %s (%s::%s, %s::uuid)",
+		k.column, k.idColumn, operator, bind(cursor.Value), k.cast, bind(cursor.ID))
+}
+
+// queryArgs collects positional query arguments
+type queryArgs struct {
+	args []any
+}
+
+// bind adds a query argument and returns its placeholder
+func (q *queryArgs) bind(value any) string {
+	q.args = append(q.args, value)
+
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// keysetPage trims the extra row fetched to detect more rows and restores the
+// display order of a page read backward. more reports whether the extra row existed.
+func keysetPage[T any](rows []T, page app.PageRequest) ([]T, bool) {
+	more := len(rows) > page.Limit
+	if more {
+		rows = rows[:page.Limit]
+	}
+
+	if page.Backward() {
+		slices.Reverse(rows)
+	}
+
+	return rows, more
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"auction/app"
+	"slices"
+	"testing"
+)
+
+func TestKeysetOrderBy(t *testing.T) {
+	ascending := keyset{column: "display_order", idColumn: "id", cast: "int"}
+	descending := keyset{column: "created_at", idColumn: "id", cast: "timestamptz", descending: true}
+
+	tests := []struct {
+		keyset   keyset
+		backward bool
+		want     string
+	}{
+		{ascending, false, "display_order ASC, id ASC"},
+		{ascending, true, "display_order DESC, id DESC"},
+		{descending, false, "created_at DESC, id DESC"},
+		{descending, true, "created_at ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.keyset.orderBy(tt.backward); got != tt.want {
+			t.Errorf("%s backward=%v: got %q, want %q", tt.keyset.column, tt.backward, got, tt.want)
+		}
+	}
+}
+
+func TestKeysetAfter(t *testing.T) {
+	descending := keyset{column: "created_at", idColumn: "id", cast: "timestamptz", descending: true}
+
+	tests := []struct {
+		backward bool
+		want     string
+	}{
+		{false, "(created_at, id) < ($1::timestamptz, $2::uuid)"},
+		{true, "(created_at, id) > ($1::timestamptz, $2::uuid)"},
+	}
+
+	for _, tt := range tests {
+		var args queryArgs
+		cursor := &app.Cursor{Value: "2026-03-01T12:00:00Z", ID: "id", Backward: tt.backward}
+
+		if got := descending.after(cursor, args.bind); got != tt.want {
+			t.Errorf("backward=%v: got %q, want %q", tt.backward, got, tt.want)
+		}
+		if !slices.Equal(args.args, []any{cursor.Value, cursor.ID}) {
+			t.Errorf("backward=%v: args = %v", tt.backward, args.args)
+		}
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	cursor := &app.Cursor{Backward: true}
+
+	tests := []struct {
+		name     string
+		rows     []int
+		page     app.PageRequest
+		want     []int
+		wantMore bool
+	}{
+		{"last page", []int{1, 2}, app.PageRequest{Limit: 3}, []int{1, 2}, false},
+		{"more rows", []int{1, 2, 3, 4}, app.PageRequest{Limit: 3}, []int{1, 2, 3}, true},
+		{"backward", []int{3, 2, 1, 0}, app.PageRequest{Cursor: cursor, Limit: 3}, []int{1, 2, 3}, true},
+	}
+
+	for _, tt := range tests {
+		rows, more := keysetPage(tt.rows, tt.page)
+		if !slices.Equal(rows, tt.want) || more != tt.wantMore {
+			t.Errorf("%s: got %v, %v; want %v, %v", tt.name, rows, more, tt.want, tt.wantMore)
+		}
+	}
+}
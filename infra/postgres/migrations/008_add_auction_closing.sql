@@ -0,0 +1,12 @@
+-- Migration: Support scheduled auction closing
+ALTER TABLE items
+    ADD COLUMN bid_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE items
+    ADD CONSTRAINT items_bid_count_positive CHECK (bid_count >= 0);
+
+COMMENT ON COLUMN items.bid_count IS
+    'Number of accepted bids. Used to decide whether an expired auction is sold.';
+
+-- Partial index for the closing scheduler which polls expired active items
+CREATE INDEX idx_items_active_end_date ON items(end_date) WHERE status = 'active';
@@ -0,0 +1,3 @@
+-- Migration: Support scheduled activation of items at start_date
+-- Partial index for the starter scheduler which polls scheduled items
+CREATE INDEX idx_items_scheduled_start_date ON items(start_date) WHERE status = 'scheduled';
@@ -0,0 +1,7 @@
+-- Migration: Restrict item status to the states of the domain state machine
+-- draft -> scheduled -> active -> ended -> sold/unsold, cancelled before the first bid
+UPDATE items SET status = 'unsold' WHERE status = 'expired';
+
+ALTER TABLE items
+    ADD CONSTRAINT items_status_valid
+        CHECK (status IN ('draft', 'scheduled', 'active', 'ended', 'sold', 'unsold', 'cancelled'));
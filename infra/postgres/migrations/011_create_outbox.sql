@@ -0,0 +1,28 @@
+-- Transactional outbox: events are written in the same transaction as the
+-- change that produced them and relayed to RabbitMQ by the worker
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Destination
+    exchange    VARCHAR(255) NOT NULL,
+    routing_key VARCHAR(255) NOT NULL,
+
+    -- Event name (e.g., "item.created") and the serialized event envelope
+    event_name VARCHAR(255) NOT NULL,
+    payload    JSONB NOT NULL,
+
+    -- Message headers
+    trace_id       VARCHAR(64),
+    correlation_id VARCHAR(64),
+
+    -- Delivery bookkeeping
+    attempts     INT NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    published_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Partial index for the relay which polls pending messages in insertion order
+CREATE INDEX idx_outbox_pending ON outbox(created_at) WHERE published_at IS NULL;
@@ -0,0 +1,9 @@
+-- Ledger of consumed events. A row is written in the same transaction as the
+-- changes made by the event handler, so a redelivered event is detected and skipped.
+CREATE TABLE IF NOT EXISTS processed_events (
+    -- Event ID, or "<event>:<bid id>" for producers that don't send one
+    event_key  VARCHAR(255) PRIMARY KEY,
+    event_name VARCHAR(255) NOT NULL,
+
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
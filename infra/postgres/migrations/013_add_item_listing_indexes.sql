@@ -0,0 +1,7 @@
+-- Indexes backing the sort orders and filters of GET /items
+CREATE INDEX idx_items_created_at    ON items(created_at DESC);
+CREATE INDEX idx_items_current_price ON items(current_price);
+CREATE INDEX idx_items_bid_count     ON items(bid_count DESC);
+
+-- Attribute filters look up one key of one item at a time
+CREATE INDEX idx_item_attributes_item_key ON item_attributes(item_id, key);
@@ -0,0 +1,84 @@
+-- Full-text search documents for items, kept in their own table so items.* is unchanged.
+-- Weights: A = name, B = description, C = attribute values
+CREATE TABLE IF NOT EXISTS item_search_documents (
+    item_id UUID PRIMARY KEY,
+
+    -- Text search configuration (dictionary) the document is built with
+    language REGCONFIG NOT NULL DEFAULT 'english',
+
+    document TSVECTOR NOT NULL,
+
+    -- Timestamps
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Foreign key to items
+    CONSTRAINT fk_item_search_documents_item FOREIGN KEY (item_id)
+        REFERENCES items(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_item_search_documents_document ON item_search_documents USING gin(document);
+
+-- Rebuilds the document of one item, keeping the language it was built with
+CREATE OR REPLACE FUNCTION refresh_item_search_document(p_item_id UUID)
+RETURNS VOID AS $$
+DECLARE
+    doc_language REGCONFIG;
+BEGIN
+    SELECT language INTO doc_language FROM item_search_documents WHERE item_id = p_item_id;
+    doc_language := COALESCE(doc_language, 'english'::regconfig);
+
+    INSERT INTO item_search_documents (item_id, language, document, updated_at)
+    SELECT
+        items.id,
+        doc_language,
+        setweight(to_tsvector(doc_language, items.name), 'A') ||
+        setweight(to_tsvector(doc_language, COALESCE(items.description, '')), 'B') ||
+        setweight(to_tsvector(doc_language, COALESCE((
+            SELECT string_agg(item_attributes.value, ' ')
+            FROM item_attributes
+            WHERE item_attributes.item_id = items.id
+        ), '')), 'C'),
+        NOW()
+    FROM items
+    WHERE items.id = p_item_id
+    ON CONFLICT (item_id) DO UPDATE SET
+        document = EXCLUDED.document,
+        updated_at = EXCLUDED.updated_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Rebuilds the document when the searchable columns of an item change
+CREATE OR REPLACE FUNCTION items_search_document_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_item_search_document(NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_items_search_document
+AFTER INSERT OR UPDATE OF name, description ON items
+FOR EACH ROW
+EXECUTE FUNCTION items_search_document_trigger();
+
+-- Rebuilds the document when the attributes of an item change
+CREATE OR REPLACE FUNCTION item_attributes_search_document_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_item_search_document(OLD.item_id);
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.item_id <> OLD.item_id) THEN
+        PERFORM refresh_item_search_document(NEW.item_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_item_attributes_search_document
+AFTER INSERT OR UPDATE OR DELETE ON item_attributes
+FOR EACH ROW
+EXECUTE FUNCTION item_attributes_search_document_trigger();
+
+-- Backfill existing items
+SELECT refresh_item_search_document(id) FROM items;
@@ -0,0 +1,17 @@
+-- Indexes backing cursor pagination: each covers the sort column followed by the id
+-- tie breaker, so a page is read by seeking to the cursor instead of skipping rows
+CREATE INDEX idx_items_created_at_id    ON items(created_at DESC, id DESC);
+CREATE INDEX idx_items_end_date_id      ON items(end_date, id);
+CREATE INDEX idx_items_current_price_id ON items(current_price, id);
+CREATE INDEX idx_items_bid_count_id     ON items(bid_count DESC, id DESC);
+
+CREATE INDEX idx_categories_created_at_id ON categories(created_at DESC, id DESC);
+
+CREATE INDEX idx_item_comments_item_created_id ON item_comments(item_id, created_at DESC, id DESC);
+CREATE INDEX idx_item_images_item_order_id     ON item_images(item_id, display_order, id);
+
+-- Superseded by the indexes above
+DROP INDEX IF EXISTS idx_items_end_date;
+DROP INDEX IF EXISTS idx_items_created_at;
+DROP INDEX IF EXISTS idx_items_current_price;
+DROP INDEX IF EXISTS idx_items_bid_count;
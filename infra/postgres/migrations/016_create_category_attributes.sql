@@ -0,0 +1,38 @@
+-- Attribute schemas of categories: the keys items of a category may or must have
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL,
+
+    -- Normalized (lowercase) attribute key, matched against item_attributes.key
+    key VARCHAR(100) NOT NULL,
+
+    -- Value type: string, number, enum, bool or date
+    type VARCHAR(16) NOT NULL,
+
+    -- Whether items of the category must have the attribute
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Allowed values of enum attributes
+    enum_values TEXT[] NOT NULL DEFAULT '{}',
+
+    -- Unit of number attributes (e.g., "mm", "kg")
+    unit VARCHAR(32),
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Foreign key to categories
+    CONSTRAINT fk_category_attributes_category FOREIGN KEY (category_id)
+        REFERENCES categories(id) ON DELETE CASCADE,
+
+    CONSTRAINT category_attributes_unique_key UNIQUE (category_id, key),
+    CONSTRAINT category_attributes_type_valid CHECK (type IN ('string', 'number', 'enum', 'bool', 'date')),
+    CONSTRAINT category_attributes_enum_values CHECK (type <> 'enum' OR cardinality(enum_values) > 0)
+);
+
+-- Trigger for updated_at
+CREATE TRIGGER trg_category_attributes_updated_at
+BEFORE UPDATE ON category_attributes
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,39 @@
+-- An item has at most one value per attribute key. Of existing duplicates the
+-- most recently updated value is kept; the others are moved to
+-- item_attribute_duplicates, with the id of the value that was kept, to be
+-- reviewed or restored by hand.
+CREATE TABLE IF NOT EXISTS item_attribute_duplicates (
+    id UUID PRIMARY KEY,
+    item_id UUID NOT NULL,
+    key VARCHAR(100) NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    kept_id UUID NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+WITH ranked AS (
+    SELECT
+        id,
+        FIRST_VALUE(id) OVER keys AS kept_id,
+        ROW_NUMBER() OVER keys AS position
+    FROM item_attributes
+    WINDOW keys AS (
+        PARTITION BY item_id, key
+        ORDER BY updated_at DESC, created_at DESC, id DESC
+    )
+), removed AS (
+    DELETE FROM item_attributes
+    USING ranked
+    WHERE item_attributes.id = ranked.id AND ranked.position > 1
+    RETURNING item_attributes.*, ranked.kept_id
+)
+INSERT INTO item_attribute_duplicates (id, item_id, key, value, created_at, updated_at, kept_id)
+SELECT id, item_id, key, value, created_at, updated_at, kept_id FROM removed;
+
+ALTER TABLE item_attributes
+    ADD CONSTRAINT item_attributes_unique_key UNIQUE (item_id, key);
+
+-- Covered by the unique constraint's index
+DROP INDEX IF EXISTS idx_item_attributes_item_id;
@@ -0,0 +1,26 @@
+-- Replies must belong to the item of their parent. Existing replies that do
+-- not are promoted to top-level comments of their own item.
+UPDATE item_comments
+SET parent_id = NULL
+FROM item_comments parents
+WHERE item_comments.parent_id = parents.id
+  AND item_comments.item_id <> parents.item_id;
+
+ALTER TABLE item_comments
+    ADD CONSTRAINT item_comments_id_item_unique UNIQUE (id, item_id);
+
+ALTER TABLE item_comments
+    DROP CONSTRAINT fk_item_comments_parent,
+    ADD CONSTRAINT fk_item_comments_parent FOREIGN KEY (parent_id, item_id)
+        REFERENCES item_comments(id, item_id) ON DELETE CASCADE;
+
+-- Top-level comments of an item, newest first, for the threaded listing
+CREATE INDEX idx_item_comments_item_roots ON item_comments(item_id, created_at DESC, id DESC)
+    WHERE parent_id IS NULL;
+
+-- Replies of a comment, oldest first
+CREATE INDEX idx_item_comments_parent_created_id ON item_comments(parent_id, created_at, id);
+
+-- Superseded by the indexes above
+DROP INDEX IF EXISTS idx_item_comments_item_parent_created;
+DROP INDEX IF EXISTS idx_item_comments_parent_id;
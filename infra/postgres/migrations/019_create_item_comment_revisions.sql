@@ -0,0 +1,26 @@
+-- When a comment was last edited; edited is derived so it cannot disagree
+ALTER TABLE item_comments
+    ADD COLUMN edited_at TIMESTAMPTZ,
+    ADD COLUMN edited BOOLEAN GENERATED ALWAYS AS (edited_at IS NOT NULL) STORED;
+
+-- Previous contents of edited comments, one row per edit
+CREATE TABLE IF NOT EXISTS item_comment_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL,
+
+    -- Content the comment had before the edit
+    content TEXT NOT NULL,
+
+    -- User who made the edit
+    edited_by UUID NOT NULL,
+
+    -- When the content was replaced
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Foreign key to comments
+    CONSTRAINT fk_item_comment_revisions_comment FOREIGN KEY (comment_id)
+        REFERENCES item_comments(id) ON DELETE CASCADE
+);
+
+-- Indexes
+CREATE INDEX idx_item_comment_revisions_comment_created ON item_comment_revisions(comment_id, created_at);
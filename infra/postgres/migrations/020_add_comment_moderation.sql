@@ -0,0 +1,35 @@
+-- Comments are no longer deleted but kept as tombstones, so replies keep their
+-- place in the thread
+ALTER TABLE item_comments
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'visible',
+    ADD COLUMN moderated_by UUID,
+    ADD COLUMN moderated_at TIMESTAMPTZ,
+    ADD COLUMN moderation_reason TEXT,
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD CONSTRAINT item_comments_status_valid CHECK (status IN ('visible', 'pending', 'hidden', 'deleted'));
+
+-- Reports of comments by users
+CREATE TABLE IF NOT EXISTS item_comment_reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL,
+
+    -- User who reported the comment
+    reporter_id UUID NOT NULL,
+
+    -- spam, abuse, off_topic or other
+    reason VARCHAR(16) NOT NULL,
+    details TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Foreign key to comments
+    CONSTRAINT fk_item_comment_reports_comment FOREIGN KEY (comment_id)
+        REFERENCES item_comments(id) ON DELETE CASCADE,
+
+    CONSTRAINT item_comment_reports_unique_reporter UNIQUE (comment_id, reporter_id),
+    CONSTRAINT item_comment_reports_reason_valid CHECK (reason IN ('spam', 'abuse', 'off_topic', 'other'))
+);
+
+-- Moderation queue, oldest first per status
+CREATE INDEX idx_item_comments_status_created ON item_comments(status, created_at, id)
+    WHERE status IN ('pending', 'hidden');
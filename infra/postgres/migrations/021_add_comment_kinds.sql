@@ -0,0 +1,10 @@
+-- Comments are plain comments, questions to the seller, or the seller's answers
+ALTER TABLE item_comments
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'comment',
+    ADD CONSTRAINT item_comments_kind_valid CHECK (kind IN ('comment', 'question', 'answer')),
+    ADD CONSTRAINT item_comments_question_is_root CHECK (kind <> 'question' OR parent_id IS NULL),
+    ADD CONSTRAINT item_comments_answer_has_parent CHECK (kind <> 'answer' OR parent_id IS NOT NULL);
+
+-- Questions of an item, oldest first, for the seller's question inbox
+CREATE INDEX idx_item_comments_item_questions ON item_comments(item_id, created_at, id)
+    WHERE kind = 'question';
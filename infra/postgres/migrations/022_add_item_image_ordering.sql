@@ -0,0 +1,27 @@
+-- Number the images of every item 0..n-1, keeping the order they were shown in
+UPDATE item_images
+SET display_order = ranked.position - 1
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY item_id ORDER BY display_order, created_at, id) AS position
+    FROM item_images
+) ranked
+WHERE item_images.id = ranked.id;
+
+-- Checked at the end of each statement so reorders can swap positions
+ALTER TABLE item_images
+    ADD CONSTRAINT item_images_item_order_unique UNIQUE (item_id, display_order)
+        DEFERRABLE INITIALLY IMMEDIATE;
+
+-- Replaced by the unique constraint's index
+DROP INDEX IF EXISTS idx_item_images_item_order;
+DROP INDEX IF EXISTS idx_item_images_item_id;
+
+-- The cover photo of an item, the first image until the seller picks another
+ALTER TABLE item_images
+    ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE item_images
+SET is_primary = TRUE
+WHERE display_order = 0;
+
+CREATE UNIQUE INDEX idx_item_images_primary ON item_images(item_id) WHERE is_primary;
@@ -0,0 +1,24 @@
+-- Images uploaded straight to storage that have not been completed yet
+CREATE TABLE IF NOT EXISTS item_image_uploads (
+    -- Becomes the id of the image once completed
+    id UUID PRIMARY KEY,
+
+    item_id UUID NOT NULL,
+
+    -- Storage key the presigned URL uploads to
+    object_key VARCHAR(500) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+
+    -- When the presigned URL stops working
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_item_image_uploads_item FOREIGN KEY (item_id)
+        REFERENCES items(id) ON DELETE CASCADE
+);
+
+-- Pending uploads of an item count towards its image limit
+CREATE INDEX idx_item_image_uploads_item_id ON item_image_uploads(item_id, expires_at);
+-- Expired uploads are swept by the worker
+CREATE INDEX idx_item_image_uploads_expires_at ON item_image_uploads(expires_at);
@@ -0,0 +1,31 @@
+-- Scaled down renditions of item images rendered by the worker
+CREATE TABLE IF NOT EXISTS item_image_variants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    image_id UUID NOT NULL,
+
+    -- Name of the rendition (thumb, medium, large, ...) and its encoding
+    size VARCHAR(50) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+
+    url VARCHAR(500) NOT NULL,
+
+    -- Actual dimensions, the aspect ratio of the image is kept
+    width INT NOT NULL,
+    height INT NOT NULL,
+    bytes BIGINT NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_item_image_variants_image FOREIGN KEY (image_id)
+        REFERENCES item_images(id) ON DELETE CASCADE,
+
+    -- Processing an image again replaces its variants
+    CONSTRAINT item_image_variants_unique UNIQUE (image_id, size, format)
+);
+
+CREATE TRIGGER trg_item_image_variants_updated_at
+BEFORE UPDATE ON item_image_variants
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,39 @@
+-- Language of an item, the text search configuration its search document is built with.
+-- Items without one keep english documents.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS language REGCONFIG;
+
+CREATE INDEX IF NOT EXISTS idx_item_search_documents_language ON item_search_documents(language);
+
+-- Rebuilds the document of one item with the language of the item
+CREATE OR REPLACE FUNCTION refresh_item_search_document(p_item_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO item_search_documents (item_id, language, document, updated_at)
+    SELECT
+        items.id,
+        doc.language,
+        setweight(to_tsvector(doc.language, items.name), 'A') ||
+        setweight(to_tsvector(doc.language, COALESCE(items.description, '')), 'B') ||
+        setweight(to_tsvector(doc.language, COALESCE((
+            SELECT string_agg(item_attributes.value, ' ')
+            FROM item_attributes
+            WHERE item_attributes.item_id = items.id
+        ), '')), 'C'),
+        NOW()
+    FROM items
+    CROSS JOIN LATERAL (SELECT COALESCE(items.language, 'english'::regconfig) AS language) doc
+    WHERE items.id = p_item_id
+    ON CONFLICT (item_id) DO UPDATE SET
+        language = EXCLUDED.language,
+        document = EXCLUDED.document,
+        updated_at = EXCLUDED.updated_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Rebuilds the document when the searchable columns or the language of an item change
+DROP TRIGGER IF EXISTS trg_items_search_document ON items;
+
+CREATE TRIGGER trg_items_search_document
+AFTER INSERT OR UPDATE OF name, description, language ON items
+FOR EACH ROW
+EXECUTE FUNCTION items_search_document_trigger();
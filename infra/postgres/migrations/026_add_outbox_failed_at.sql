@@ -0,0 +1,9 @@
+-- Messages that cannot be relayed are parked once they fail for good, so they
+-- no longer block the messages behind them
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox(created_at) WHERE published_at IS NULL AND failed_at IS NULL;
+
+-- Parked messages, to be inspected and requeued by clearing failed_at
+CREATE INDEX idx_outbox_failed ON outbox(failed_at) WHERE failed_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Author of the current highest bid, recorded from bid.placed so the auction
+-- closer can name the buyer when it settles an item as sold
+ALTER TABLE items ADD COLUMN IF NOT EXISTS high_bidder_id UUID;
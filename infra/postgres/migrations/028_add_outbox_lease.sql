@@ -0,0 +1,4 @@
+-- The relay leases the messages it publishes instead of keeping their rows
+-- locked, so no transaction stays open while it waits for the broker. Messages
+-- of a relay that stopped are claimed again once their lease expires.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
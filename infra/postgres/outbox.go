@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"auction/pkg/events"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// OutboxPublisher implements events.Publisher by writing events to the outbox
+// table. When called with a context from WithinTransaction the event is stored
+// in the same transaction as the change that produced it.
+type OutboxPublisher struct {
+	repository *PgRepository
+}
+
+func NewOutboxPublisher(repository *PgRepository) *OutboxPublisher {
+	return &OutboxPublisher{
+		repository: repository,
+	}
+}
+
+// Publish stores the event in the outbox; the worker relays it to the exchange
+func (p *OutboxPublisher) Publish(ctx context.Context, exchange string, event *events.Event, headers events.Headers) error {
+	body, err := event.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (exchange, routing_key, event_name, payload, trace_id, correlation_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = p.repository.conn(ctx).ExecContext(ctx, query,
+		exchange,
+		event.GetRoutingKey(),
+		event.Event,
+		body,
+		headers.TraceID,
+		headers.CorrelationID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write event to outbox: %w", err)
+	}
+
+	return nil
+}
+
+// Close is a no-op; the connection pool is owned by the repository
+func (p *OutboxPublisher) Close() error {
+	return nil
+}
+
+// ClaimOutboxMessages leases up to limit pending messages in insertion order
+// for lease, skipping parked messages and messages leased by another relay.
+// The claim is a single statement that commits on its own, so no row stays
+// locked while the messages are published.
+func (r *PgRepository) ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxMessage, error) {
+	messages := make([]events.OutboxMessage, 0)
+
+	query := `
+		WITH claimed AS (
+			UPDATE outbox SET locked_until = NOW() + $2 * INTERVAL '1 millisecond'
+			WHERE id IN (
+				SELECT id FROM outbox
+				WHERE published_at IS NULL AND failed_at IS NULL
+				AND (locked_until IS NULL OR locked_until < NOW())
+				ORDER BY created_at ASC
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT * FROM claimed ORDER BY created_at ASC`
+
+	err := r.conn(ctx).SelectContext(ctx, &messages, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *PgRepository) MarkOutboxMessagePublished(ctx context.Context, id string) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		"UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL, locked_until = NULL WHERE id = $1",
+		id,
+	)
+
+	return err
+}
+
+// ReleaseOutboxMessages ends the lease of messages that were claimed but not
+// published, so the next run claims them again right away
+func (r *PgRepository) ReleaseOutboxMessages(ctx context.Context, ids []string) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		"UPDATE outbox SET locked_until = NULL WHERE id = ANY($1)",
+		pq.Array(ids),
+	)
+
+	return err
+}
+
+// MarkOutboxMessageFailed records a failed attempt to relay a message. The
+// message is parked, and no longer claimed, when park is true.
+func (r *PgRepository) MarkOutboxMessageFailed(ctx context.Context, id string, reason string, park bool) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		`UPDATE outbox SET
+			attempts = attempts + 1,
+			last_error = $2,
+			failed_at = CASE WHEN $3 THEN NOW() END,
+			locked_until = NULL
+		WHERE id = $1`,
+		id, reason, park,
+	)
+
+	return err
+}
+
+// DeletePublishedOutboxMessages removes messages published before the given time
+func (r *PgRepository) DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.conn(ctx).ExecContext(ctx,
+		"DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < $1",
+		before,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
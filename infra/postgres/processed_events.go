@@ -0,0 +1,25 @@
+package postgres
+
+import "context"
+
+// MarkEventProcessed records a consumed event. It returns false when the key was
+// already recorded, meaning the event is a duplicate delivery. Called within a
+// transaction, a concurrent duplicate blocks until the first delivery commits.
+func (r *PgRepository) MarkEventProcessed(ctx context.Context, key string, eventName string) (bool, error) {
+	query := `
+		INSERT INTO processed_events (event_key, event_name)
+		VALUES ($1, $2)
+		ON CONFLICT (event_key) DO NOTHING`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, key, eventName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
@@ -12,7 +12,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type PgRepository struct {
@@ -55,69 +55,101 @@ func (r *PgRepository) GetPoolStats() map[string]any {
 }
 
 func (r *PgRepository) Create(ctx context.Context, req *app.CreateItemRequest) (domain.Item, error) {
-	// Start transaction
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return domain.Item{}, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback() // Will be no-op if transaction is committed
+	var item domain.Item
+
+	// Joins the caller's transaction when there is one
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := r.conn(ctx)
+
+		// Insert item using positional parameters
+		var itemID string
+		query := `
+			INSERT INTO items (
+				name, description, seller_id, currency_code,
+				start_price, bid_increment, reserve_price,
+				buyout_price, end_price, start_date, end_date,
+				status, extension_threshold_minutes, extension_duration_minutes,
+				language
+			) VALUES (
+				$1, $2, $3, $4,
+				$5, $6, $7,
+				$8, $9, $10, $11,
+				$12, $13, $14,
+				$15
+			) RETURNING id`
+
+		err := tx.QueryRowxContext(ctx, query,
+			req.Name,
+			req.Description,
+			req.SellerID,
+			req.CurrencyCode,
+			req.StartPrice,
+			req.BidIncrement,
+			req.ReservePrice,
+			req.BuyoutPrice,
+			req.EndPrice,
+			req.StartDate,
+			req.EndDate,
+			req.Status,
+			req.ExtensionThresholdMinutes,
+			req.ExtensionDurationMinutes,
+			req.Language,
+		).Scan(&itemID)
+
+		if err != nil {
+			return err
+		}
 
-	// Insert item using positional parameters
-	var itemID string
-	query := `
-		INSERT INTO items (
-			name, description, seller_id, currency_code,
-			start_price, bid_increment, reserve_price,
-			buyout_price, end_price, start_date, end_date,
-			status, extension_threshold_minutes, extension_duration_minutes
-		) VALUES (
-			$1, $2, $3, $4,
-			$5, $6, $7,
-			$8, $9, $10, $11,
-			$12, $13, $14
-		) RETURNING id`
-
-	err = tx.QueryRowContext(ctx, query,
-		req.Name,
-		req.Description,
-		req.SellerID,
-		req.CurrencyCode,
-		req.StartPrice,
-		req.BidIncrement,
-		req.ReservePrice,
-		req.BuyoutPrice,
-		req.EndPrice,
-		req.StartDate,
-		req.EndDate,
-		req.Status,
-		req.ExtensionThresholdMinutes,
-		req.ExtensionDurationMinutes,
-	).Scan(&itemID)
+		// Insert item categories if provided
+		if len(req.CategoryIDs) > 0 {
+			categoryQuery := `INSERT INTO item_categories (item_id, category_id) VALUES ($1, $2)`
+			for _, categoryID := range req.CategoryIDs {
+				if _, err := tx.ExecContext(ctx, categoryQuery, itemID, categoryID); err != nil {
+					return fmt.Errorf("failed to insert item category: %w", err)
+				}
+			}
+		}
 
+		// Fetch the complete item with categories
+		item, err = r.GetItem(ctx, itemID)
+		return err
+	})
 	if err != nil {
 		return domain.Item{}, err
 	}
 
-	// Insert item categories if provided
-	if len(req.CategoryIDs) > 0 {
-		categoryQuery := `INSERT INTO item_categories (item_id, category_id) VALUES ($1, $2)`
-		for _, categoryID := range req.CategoryIDs {
-			if _, err := tx.ExecContext(ctx, categoryQuery, itemID, categoryID); err != nil {
-				return domain.Item{}, fmt.Errorf("failed to insert item category: %w", err)
-			}
-		}
+	return item, nil
+}
+
+func (r *PgRepository) GetItems(ctx context.Context, filter app.ItemFilter, limit, offset int) ([]domain.Item, error) {
+	q := newItemQuery(filter)
+	orderBy := q.keyset(filter.Sort).orderBy(false)
+
+	return r.selectItems(ctx, q, orderBy, "LIMIT "+q.bind(limit)+" OFFSET "+q.bind(offset))
+}
+
+// GetItemsPage returns the page of items following the cursor in the sort
+// order of the filter, and whether more items exist beyond it
+func (r *PgRepository) GetItemsPage(ctx context.Context, filter app.ItemFilter, page app.PageRequest) ([]domain.Item, bool, error) {
+	q := newItemQuery(filter)
+	keyset := q.keyset(filter.Sort)
+
+	if page.Cursor != nil {
+		q.where(keyset.after(page.Cursor, q.bind))
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return domain.Item{}, fmt.Errorf("failed to commit transaction: %w", err)
+	items, err := r.selectItems(ctx, q, keyset.orderBy(page.Backward()), "LIMIT "+q.bind(page.Limit+1))
+	if err != nil {
+		return nil, false, err
 	}
 
-	// Fetch and return the complete item with categories
-	return r.GetItem(ctx, itemID)
+	items, more := keysetPage(items, page)
+
+	return items, more, nil
 }
 
-func (r *PgRepository) GetItems(ctx context.Context, limit, offset int) ([]domain.Item, error) {
+// selectItems runs an item listing query with the categories of each item
+func (r *PgRepository) selectItems(ctx context.Context, q *itemQuery, orderBy string, limit string) ([]domain.Item, error) {
 	// Temporary struct to hold the query result with JSON categories
 	type itemWithCategories struct {
 		domain.Item
@@ -144,12 +176,13 @@ func (r *PgRepository) GetItems(ctx context.Context, limit, offset int) ([]domai
 		FROM items
 		LEFT JOIN item_categories ON items.id = item_categories.item_id
 		LEFT JOIN categories ON item_categories.category_id = categories.id
+		` + q.whereClause() + `
 		GROUP BY items.id
-		ORDER BY items.created_at DESC
-		LIMIT $1 OFFSET $2`
+		ORDER BY ` + orderBy + `
+		` + limit
 
 	var tempItems []itemWithCategories
-	err := r.db.SelectContext(ctx, &tempItems, query, limit, offset)
+	err := r.conn(ctx).SelectContext(ctx, &tempItems, query, q.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +209,7 @@ func (r *PgRepository) GetCategories(ctx context.Context, limit, offset int) ([]
 	categories := make([]domain.Category, 0)
 	query := `SELECT * FROM categories ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
-	err := r.db.SelectContext(ctx, &categories, query, limit, offset)
+	err := r.conn(ctx).SelectContext(ctx, &categories, query, limit, offset)
 
 	if err != nil {
 		return nil, err
@@ -185,11 +218,34 @@ func (r *PgRepository) GetCategories(ctx context.Context, limit, offset int) ([]
 	return categories, nil
 }
 
-func (r *PgRepository) CountItems(ctx context.Context) (int, error) {
+// GetCategoriesPage returns the page of categories following the cursor, newest
+// first, and whether more categories exist beyond it
+func (r *PgRepository) GetCategoriesPage(ctx context.Context, page app.PageRequest) ([]domain.Category, bool, error) {
+	categories := make([]domain.Category, 0)
+	q := &queryArgs{}
+
+	query := `SELECT * FROM categories`
+	if page.Cursor != nil {
+		query += ` WHERE ` + categoryKeyset.after(page.Cursor, q.bind)
+	}
+	query += ` ORDER BY ` + categoryKeyset.orderBy(page.Backward()) + ` LIMIT ` + q.bind(page.Limit+1)
+
+	err := r.conn(ctx).SelectContext(ctx, &categories, query, q.args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	categories, more := keysetPage(categories, page)
+
+	return categories, more, nil
+}
+
+func (r *PgRepository) CountItems(ctx context.Context, filter app.ItemFilter) (int, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM items`
+	q := newItemQuery(filter)
+	query := `SELECT COUNT(*) FROM items ` + q.whereClause()
 
-	err := r.db.GetContext(ctx, &count, query)
+	err := r.conn(ctx).GetContext(ctx, &count, query, q.args...)
 	if err != nil {
 		return 0, err
 	}
@@ -201,7 +257,7 @@ func (r *PgRepository) CountCategories(ctx context.Context) (int, error) {
 	var count int
 	query := `SELECT COUNT(*) FROM categories`
 
-	err := r.db.GetContext(ctx, &count, query)
+	err := r.conn(ctx).GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, err
 	}
@@ -240,7 +296,7 @@ func (r *PgRepository) GetItem(ctx context.Context, id string) (domain.Item, err
 		GROUP BY items.id`
 
 	var temp itemWithCategories
-	err := r.db.GetContext(ctx, &temp, query, id)
+	err := r.conn(ctx).GetContext(ctx, &temp, query, id)
 	if err != nil {
 		return domain.Item{}, err
 	}
@@ -290,7 +346,7 @@ func (r *PgRepository) GetUserItem(ctx context.Context, id string, userId string
 		GROUP BY items.id`
 
 	var temp itemWithCategories
-	err := r.db.GetContext(ctx, &temp, query, id, userId)
+	err := r.conn(ctx).GetContext(ctx, &temp, query, id, userId)
 	if err != nil {
 		return domain.Item{}, err
 	}
@@ -312,7 +368,7 @@ func (r *PgRepository) GetUserItem(ctx context.Context, id string, userId string
 func (r *PgRepository) DeleteItem(ctx context.Context, id string, userId string) error {
 	query := `DELETE FROM items WHERE id = $1 AND seller_id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, id, userId)
+	_, err := r.conn(ctx).ExecContext(ctx, query, id, userId)
 
 	return err
 }
@@ -324,37 +380,49 @@ func (r *PgRepository) UpdateUserItem(ctx context.Context, item domain.Item, use
             description = :description,
             seller_id = :seller_id,
             currency_code = :currency_code,
+            language = :language,
             start_price = :start_price,
             bid_increment = :bid_increment,
             reserve_price = :reserve_price,
             buyout_price = :buyout_price,
             end_price = :end_price,
+            extension_threshold_minutes = :extension_threshold_minutes,
+            extension_duration_minutes = :extension_duration_minutes,
             start_date = :start_date,
             end_date = :end_date,
-            status = :status
-        WHERE id = :id AND seller_id = :seller_id_filter
+            status = :status,
+            version = version + 1
+        WHERE id = :id AND seller_id = :seller_id_filter AND version = :version
     `
 
 	// named param map: item alanları + seller_id_filter (WHERE için)
 	params := map[string]interface{}{
-		"id":               item.ID,
-		"name":             item.Name,
-		"description":      item.Description,
-		"seller_id":        item.SellerID,
-		"currency_code":    item.CurrencyCode,
-		"start_price":      item.StartPrice,
-		"bid_increment":    item.BidIncrement,
-		"reserve_price":    item.ReservePrice,
-		"buyout_price":     item.BuyoutPrice,
-		"end_price":        item.EndPrice,
-		"start_date":       item.StartDate,
-		"end_date":         item.EndDate,
-		"status":           item.Status,
-		"seller_id_filter": userId,
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	return err
+		"id":                          item.ID,
+		"name":                        item.Name,
+		"description":                 item.Description,
+		"seller_id":                   item.SellerID,
+		"currency_code":               item.CurrencyCode,
+		"language":                    item.Language,
+		"start_price":                 item.StartPrice,
+		"bid_increment":               item.BidIncrement,
+		"reserve_price":               item.ReservePrice,
+		"buyout_price":                item.BuyoutPrice,
+		"end_price":                   item.EndPrice,
+		"start_date":                  item.StartDate,
+		"end_date":                    item.EndDate,
+		"status":                      item.Status,
+		"version":                     item.Version,
+		"seller_id_filter":            userId,
+		"extension_threshold_minutes": item.ExtensionThresholdMinutes,
+		"extension_duration_minutes":  item.ExtensionDurationMinutes,
+	}
+
+	result, err := r.conn(ctx).NamedExecContext(ctx, query, params)
+	if err != nil {
+		return err
+	}
+
+	return checkOptimisticLock(result)
 }
 
 func (r *PgRepository) Update(ctx context.Context, item domain.Item) error {
@@ -375,8 +443,11 @@ func (r *PgRepository) Update(ctx context.Context, item domain.Item) error {
             start_date = :start_date,
             end_date = :end_date,
             status = :status,
+            buyer_id = :buyer_id,
+            high_bidder_id = :high_bidder_id,
+            bid_count = :bid_count,
             version = version + 1
-        WHERE id = :id
+        WHERE id = :id AND version = :version
     `
 
 	params := map[string]any{
@@ -396,17 +467,80 @@ func (r *PgRepository) Update(ctx context.Context, item domain.Item) error {
 		"extension_threshold_minutes": item.ExtensionThresholdMinutes,
 		"extension_duration_minutes":  item.ExtensionDurationMinutes,
 		"status":                      item.Status,
+		"buyer_id":                    item.BuyerID,
+		"high_bidder_id":              item.HighBidderID,
+		"bid_count":                   item.BidCount,
 		"version":                     item.Version,
 	}
 
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	return err
+	result, err := r.conn(ctx).NamedExecContext(ctx, query, params)
+	if err != nil {
+		return err
+	}
+
+	return checkOptimisticLock(result)
+}
+
+// ClaimExpiredItems locks up to limit active items whose end date has passed.
+// Rows already locked by another worker are skipped, so the call must run
+// within a transaction that also applies the resulting updates.
+func (r *PgRepository) ClaimExpiredItems(ctx context.Context, before time.Time, limit int) ([]domain.Item, error) {
+	items := make([]domain.Item, 0)
+
+	query := `
+		SELECT * FROM items
+		WHERE status = $1 AND end_date <= $2
+		ORDER BY end_date ASC
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`
+
+	err := r.conn(ctx).SelectContext(ctx, &items, query, domain.ItemStatusActive, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ClaimScheduledItems locks up to limit scheduled items whose start date has
+// arrived, skipping rows locked by another worker. Must run within a transaction.
+func (r *PgRepository) ClaimScheduledItems(ctx context.Context, before time.Time, limit int) ([]domain.Item, error) {
+	items := make([]domain.Item, 0)
+
+	query := `
+		SELECT * FROM items
+		WHERE status = $1 AND start_date <= $2
+		ORDER BY start_date ASC
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`
+
+	err := r.conn(ctx).SelectContext(ctx, &items, query, domain.ItemStatusScheduled, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// checkOptimisticLock reports app.ErrOptimisticLock when a versioned UPDATE
+// matched no rows.
+func checkOptimisticLock(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return app.ErrOptimisticLock
+	}
+
+	return nil
 }
 
 func (r *PgRepository) GetCategoryByID(ctx context.Context, id string) (domain.Category, error) {
 	var category domain.Category
 
-	err := r.db.GetContext(ctx, &category, "SELECT * FROM categories WHERE id = $1", id)
+	err := r.conn(ctx).GetContext(ctx, &category, "SELECT * FROM categories WHERE id = $1", id)
 	if err != nil {
 		return category, err
 	}
@@ -417,7 +551,7 @@ func (r *PgRepository) GetCategoryByID(ctx context.Context, id string) (domain.C
 func (r *PgRepository) GetCategoriesByItemID(ctx context.Context, itemId string) ([]domain.Category, error) {
 	categories := make([]domain.Category, 0)
 
-	err := r.db.SelectContext(ctx, &categories, "SELECT * FROM categories WHERE id IN (SELECT category_id FROM item_categories WHERE item_id = $1)", itemId)
+	err := r.conn(ctx).SelectContext(ctx, &categories, "SELECT * FROM categories WHERE id IN (SELECT category_id FROM item_categories WHERE item_id = $1)", itemId)
 	if err != nil {
 		return categories, err
 	}
@@ -431,7 +565,7 @@ func (r *PgRepository) GetItemCommentsByItemID(ctx context.Context, itemID strin
 	limit := pageSize
 	offset := (page - 1) * pageSize
 
-	err := r.db.SelectContext(ctx, &comments, "SELECT * FROM item_comments WHERE item_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3", itemID, limit, offset)
+	err := r.conn(ctx).SelectContext(ctx, &comments, "SELECT * FROM item_comments WHERE item_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3", itemID, limit, offset)
 	if err != nil {
 		return comments, err
 	}
@@ -439,10 +573,32 @@ func (r *PgRepository) GetItemCommentsByItemID(ctx context.Context, itemID strin
 	return comments, nil
 }
 
+// GetItemCommentsPage returns the page of comments of an item following the
+// cursor, newest first, and whether more comments exist beyond it
+func (r *PgRepository) GetItemCommentsPage(ctx context.Context, itemID string, page app.PageRequest) ([]domain.ItemComment, bool, error) {
+	comments := make([]domain.ItemComment, 0)
+	q := &queryArgs{}
+
+	query := `SELECT * FROM item_comments WHERE item_id = ` + q.bind(itemID)
+	if page.Cursor != nil {
+		query += ` AND ` + commentKeyset.after(page.Cursor, q.bind)
+	}
+	query += ` ORDER BY ` + commentKeyset.orderBy(page.Backward()) + ` LIMIT ` + q.bind(page.Limit+1)
+
+	err := r.conn(ctx).SelectContext(ctx, &comments, query, q.args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments, more := keysetPage(comments, page)
+
+	return comments, more, nil
+}
+
 func (r *PgRepository) CountItemComments(ctx context.Context, itemID string) (int, error) {
 	var count int
 
-	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM item_comments WHERE item_id = $1", itemID)
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_comments WHERE item_id = $1", itemID)
 	if err != nil {
 		return 0, err
 	}
@@ -450,39 +606,53 @@ func (r *PgRepository) CountItemComments(ctx context.Context, itemID string) (in
 	return count, nil
 }
 
-func (r *PgRepository) CreateComment(ctx context.Context, itemID string, content string, userID string, parentID *string) (domain.ItemComment, error) {
+func (r *PgRepository) CreateComment(ctx context.Context, comment domain.ItemComment) (domain.ItemComment, error) {
 	query := `
-		INSERT INTO item_comments (item_id, content, user_id, parent_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, item_id, content, user_id, parent_id, created_at, updated_at
+		INSERT INTO item_comments (item_id, content, user_id, parent_id, kind, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING *
 	`
 
-	var comment domain.ItemComment
-	err := r.db.GetContext(ctx, &comment, query, itemID, content, userID, parentID)
+	var created domain.ItemComment
+	err := r.conn(ctx).GetContext(ctx, &created, query, comment.ItemID, comment.Content, comment.UserID, comment.ParentID, comment.Kind, comment.Status)
 	if err != nil {
 		return domain.ItemComment{}, err
 	}
 
-	return comment, nil
+	return created, nil
 }
 
-func (r *PgRepository) DeleteComment(ctx context.Context, id string) error {
+// UpdateComment replaces the content of a comment and keeps the previous
+// content as a revision. The row is locked first, so concurrent edits each
+// store the content they replaced. Only visible comments are edited; others
+// return sql.ErrNoRows.
+func (r *PgRepository) UpdateComment(ctx context.Context, id string, content string, status domain.CommentStatus, editedBy string) (domain.ItemComment, error) {
 	query := `
-		DELETE FROM item_comments WHERE id = $1
+		WITH previous AS (
+			SELECT id, content FROM item_comments WHERE id = $1 AND status = 'visible' FOR UPDATE
+		), revision AS (
+			INSERT INTO item_comment_revisions (comment_id, content, edited_by)
+			SELECT id, content, $3 FROM previous
+		)
+		UPDATE item_comments SET content = $2, status = $4, edited_at = NOW()
+		FROM previous
+		WHERE item_comments.id = previous.id
+		RETURNING item_comments.*
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	var comment domain.ItemComment
+	err := r.conn(ctx).GetContext(ctx, &comment, query, id, content, editedBy, status)
 	if err != nil {
-		return err
+		return domain.ItemComment{}, err
 	}
 
-	return nil
+	return comment, nil
 }
 
 func (r *PgRepository) GetCommentByID(ctx context.Context, id string) (domain.ItemComment, error) {
 	var comment domain.ItemComment
 
-	err := r.db.GetContext(ctx, &comment, "SELECT * FROM item_comments WHERE id = $1", id)
+	err := r.conn(ctx).GetContext(ctx, &comment, "SELECT * FROM item_comments WHERE id = $1", id)
 	if err != nil {
 		return domain.ItemComment{}, err
 	}
@@ -495,7 +665,7 @@ func (r *PgRepository) GetItemImages(ctx context.Context, itemID string, page, p
 
 	offset := (page - 1) * pageSize
 
-	err := r.db.SelectContext(ctx, &images, "SELECT * FROM item_images WHERE item_id = $1 ORDER BY display_order ASC LIMIT $2 OFFSET $3", itemID, pageSize, offset)
+	err := r.conn(ctx).SelectContext(ctx, &images, "SELECT * FROM item_images WHERE item_id = $1 ORDER BY display_order ASC LIMIT $2 OFFSET $3", itemID, pageSize, offset)
 	if err != nil {
 		return images, err
 	}
@@ -503,10 +673,32 @@ func (r *PgRepository) GetItemImages(ctx context.Context, itemID string, page, p
 	return images, nil
 }
 
+// GetItemImagesPage returns the page of images of an item following the cursor
+// in display order, and whether more images exist beyond it
+func (r *PgRepository) GetItemImagesPage(ctx context.Context, itemID string, page app.PageRequest) ([]domain.ItemImage, bool, error) {
+	images := make([]domain.ItemImage, 0)
+	q := &queryArgs{}
+
+	query := `SELECT * FROM item_images WHERE item_id = ` + q.bind(itemID)
+	if page.Cursor != nil {
+		query += ` AND ` + imageKeyset.after(page.Cursor, q.bind)
+	}
+	query += ` ORDER BY ` + imageKeyset.orderBy(page.Backward()) + ` LIMIT ` + q.bind(page.Limit+1)
+
+	err := r.conn(ctx).SelectContext(ctx, &images, query, q.args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	images, more := keysetPage(images, page)
+
+	return images, more, nil
+}
+
 func (r *PgRepository) CountItemImages(ctx context.Context, itemID string) (int, error) {
 	var count int
 
-	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM item_images WHERE item_id = $1", itemID)
+	err := r.conn(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM item_images WHERE item_id = $1", itemID)
 	if err != nil {
 		return 0, err
 	}
@@ -514,15 +706,19 @@ func (r *PgRepository) CountItemImages(ctx context.Context, itemID string) (int,
 	return count, nil
 }
 
+// SaveImage adds an image after the last one of the item. The first image of
+// an item becomes its primary image. Callers hold LockItemImages.
 func (r *PgRepository) SaveImage(ctx context.Context, itemID string, imageUrl string) (domain.ItemImage, error) {
 	query := `
-		INSERT INTO item_images (item_id, url)
-		VALUES ($1, $2)
-		RETURNING id, item_id, url, display_order, created_at, updated_at
+		INSERT INTO item_images (item_id, url, display_order, is_primary)
+		SELECT $1, $2, COALESCE(MAX(display_order) + 1, 0), NOT COALESCE(BOOL_OR(is_primary), FALSE)
+		FROM item_images
+		WHERE item_id = $1
+		RETURNING *
 	`
 
 	var image domain.ItemImage
-	err := r.db.GetContext(ctx, &image, query, itemID, imageUrl)
+	err := r.conn(ctx).GetContext(ctx, &image, query, itemID, imageUrl)
 	if err != nil {
 		return domain.ItemImage{}, err
 	}
@@ -530,13 +726,44 @@ func (r *PgRepository) SaveImage(ctx context.Context, itemID string, imageUrl st
 	return image, nil
 }
 
+// DeleteItemImage deletes an image and closes the gap it leaves in the display
+// order. When it was the primary image, the first remaining one takes over.
+// Callers hold LockItemImages.
 func (r *PgRepository) DeleteItemImage(ctx context.Context, itemID string, imageID string) error {
 	query := `
 		DELETE FROM item_images
 		WHERE id = $1 AND item_id = $2
 	`
 
-	_, err := r.db.ExecContext(ctx, query, imageID, itemID)
+	_, err := r.conn(ctx).ExecContext(ctx, query, imageID, itemID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE item_images
+		SET display_order = ranked.position - 1
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY display_order, id) AS position
+			FROM item_images
+			WHERE item_id = $1
+		) ranked
+		WHERE item_images.id = ranked.id AND item_images.display_order <> ranked.position - 1
+	`
+
+	_, err = r.conn(ctx).ExecContext(ctx, query, itemID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE item_images
+		SET is_primary = TRUE
+		WHERE item_id = $1 AND display_order = 0
+		AND NOT EXISTS (SELECT 1 FROM item_images WHERE item_id = $1 AND is_primary)
+	`
+
+	_, err = r.conn(ctx).ExecContext(ctx, query, itemID)
 	if err != nil {
 		return err
 	}
@@ -547,7 +774,7 @@ func (r *PgRepository) DeleteItemImage(ctx context.Context, itemID string, image
 func (r *PgRepository) GetItemImage(ctx context.Context, itemId string, imageId string) (domain.ItemImage, error) {
 	var image domain.ItemImage
 
-	err := r.db.GetContext(ctx, &image, "SELECT * FROM item_images WHERE id = $1 AND item_id = $2", imageId, itemId)
+	err := r.conn(ctx).GetContext(ctx, &image, "SELECT * FROM item_images WHERE id = $1 AND item_id = $2", imageId, itemId)
 	if err != nil {
 		return domain.ItemImage{}, err
 	}
@@ -558,7 +785,7 @@ func (r *PgRepository) GetItemImage(ctx context.Context, itemId string, imageId
 func (r *PgRepository) GetItemAttributes(ctx context.Context, itemID string) ([]domain.ItemAttribute, error) {
 	attributes := make([]domain.ItemAttribute, 0)
 
-	err := r.db.SelectContext(ctx, &attributes, "SELECT * FROM item_attributes WHERE item_id = $1", itemID)
+	err := r.conn(ctx).SelectContext(ctx, &attributes, "SELECT * FROM item_attributes WHERE item_id = $1", itemID)
 	if err != nil {
 		return attributes, err
 	}
@@ -569,7 +796,7 @@ func (r *PgRepository) GetItemAttributes(ctx context.Context, itemID string) ([]
 func (r *PgRepository) GetItemAttribute(ctx context.Context, itemID string, attributeID string) (domain.ItemAttribute, error) {
 	var attribute domain.ItemAttribute
 
-	err := r.db.GetContext(ctx, &attribute, "SELECT * FROM item_attributes WHERE id = $1 AND item_id = $2", attributeID, itemID)
+	err := r.conn(ctx).GetContext(ctx, &attribute, "SELECT * FROM item_attributes WHERE id = $1 AND item_id = $2", attributeID, itemID)
 	if err != nil {
 		return domain.ItemAttribute{}, err
 	}
@@ -598,18 +825,55 @@ func (r *PgRepository) CreateItemAttributes(ctx context.Context, attributes []do
 	)
 
 	result := make([]domain.ItemAttribute, 0)
-	err := r.db.SelectContext(ctx, &result, query, values...)
+	err := r.conn(ctx).SelectContext(ctx, &result, query, values...)
 	if err != nil {
 		return result, err
 	}
 	return result, nil
 }
 
+// UpdateItemAttribute changes the key and value of an attribute. It returns
+// sql.ErrNoRows when the item has no attribute with the id.
+func (r *PgRepository) UpdateItemAttribute(ctx context.Context, attribute domain.ItemAttribute) (domain.ItemAttribute, error) {
+	var updated domain.ItemAttribute
+
+	query := `
+		UPDATE item_attributes SET key = $3, value = $4
+		WHERE id = $1 AND item_id = $2
+		RETURNING id, item_id, key, value, created_at, updated_at
+	`
+
+	err := r.conn(ctx).GetContext(ctx, &updated, query, attribute.ID, attribute.ItemID, attribute.Key, attribute.Value)
+	if err != nil {
+		return domain.ItemAttribute{}, err
+	}
+
+	return updated, nil
+}
+
 func (r *PgRepository) DeleteItemAttribute(ctx context.Context, itemID string, attributeID string) error {
-	_, err := r.db.ExecContext(ctx, "DELETE FROM item_attributes WHERE id = $1 AND item_id = $2", attributeID, itemID)
+	_, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM item_attributes WHERE id = $1 AND item_id = $2", attributeID, itemID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (r *PgRepository) DeleteItemAttributes(ctx context.Context, itemID string, attributeIDs []string) error {
+	if len(attributeIDs) == 0 {
+		return nil
+	}
+
+	_, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM item_attributes WHERE item_id = $1 AND id = ANY($2::uuid[])", itemID, pq.Array(attributeIDs))
+
+	return err
+}
+
+// LockItemAttributes serializes changes to the attributes of an item until the
+// surrounding transaction ends, so concurrent edits diff against current rows
+func (r *PgRepository) LockItemAttributes(ctx context.Context, itemID string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('item_attributes:' || $1::text))", itemID)
+
+	return err
+}
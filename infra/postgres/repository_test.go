@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"auction/app"
+	"auction/domain"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type fakeResult struct {
+	rows int64
+	err  error
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rows, r.err }
+
+func TestCheckOptimisticLock(t *testing.T) {
+	driverErr := errors.New("driver error")
+
+	if err := checkOptimisticLock(fakeResult{rows: 1}); err != nil {
+		t.Errorf("updated row: err = %v, want nil", err)
+	}
+	if err := checkOptimisticLock(fakeResult{rows: 0}); !errors.Is(err, app.ErrOptimisticLock) {
+		t.Errorf("stale version: err = %v, want %v", err, app.ErrOptimisticLock)
+	}
+	if err := checkOptimisticLock(fakeResult{err: driverErr}); !errors.Is(err, driverErr) || errors.Is(err, app.ErrOptimisticLock) {
+		t.Errorf("driver error: err = %v, want it wrapped", err)
+	}
+}
+
+// versionedItemsDriver stands in for Postgres with a single item row at a
+// version. Updates of items match the row only when their last argument, the
+// version guard of the WHERE clause, is the current version.
+type versionedItemsDriver struct {
+	version int64
+}
+
+func (d *versionedItemsDriver) Open(string) (driver.Conn, error) { return &versionedItemsConn{d}, nil }
+
+type versionedItemsConn struct{ driver *versionedItemsDriver }
+
+func (c *versionedItemsConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+func (c *versionedItemsConn) Close() error { return nil }
+func (c *versionedItemsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("begin is not supported")
+}
+
+func (c *versionedItemsConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if !strings.Contains(query, "UPDATE items SET") || !strings.Contains(query, "version = version + 1") {
+		return nil, errors.New("unexpected query")
+	}
+
+	if version, ok := args[len(args)-1].Value.(int64); !ok || version != c.driver.version {
+		return driver.RowsAffected(0), nil
+	}
+	c.driver.version++
+
+	return driver.RowsAffected(1), nil
+}
+
+func newVersionedRepository(t *testing.T, version int64) *PgRepository {
+	t.Helper()
+
+	db := sql.OpenDB(&versionedItemsConnector{&versionedItemsDriver{version: version}})
+	t.Cleanup(func() { db.Close() })
+
+	return &PgRepository{db: sqlx.NewDb(db, "postgres")}
+}
+
+type versionedItemsConnector struct{ driver *versionedItemsDriver }
+
+func (c *versionedItemsConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+func (c *versionedItemsConnector) Driver() driver.Driver { return c.driver }
+
+func TestUpdateVersionConflict(t *testing.T) {
+	ctx := context.Background()
+
+	updates := map[string]func(r *PgRepository, item domain.Item) error{
+		"Update": func(r *PgRepository, item domain.Item) error {
+			return r.Update(ctx, item)
+		},
+		"UpdateUserItem": func(r *PgRepository, item domain.Item) error {
+			return r.UpdateUserItem(ctx, item, item.SellerID)
+		},
+	}
+
+	for name, update := range updates {
+		repository := newVersionedRepository(t, 3)
+		item := domain.Item{ID: "item", SellerID: "seller", Status: domain.ItemStatusActive, Version: 3}
+
+		if err := update(repository, item); err != nil {
+			t.Errorf("%s at the current version: err = %v, want nil", name, err)
+		}
+
+		// The row moved on to version 4, so the copy read at version 3 is stale
+		if err := update(repository, item); !errors.Is(err, app.ErrOptimisticLock) {
+			t.Errorf("%s at a stale version: err = %v, want %v", name, err, app.ErrOptimisticLock)
+		}
+	}
+}
@@ -262,6 +262,11 @@ func (c *Consumer) handleMessage(ctx context.Context, msg amqp.Delivery, handler
 		return
 	}
 
+	// Fall back to the AMQP message ID for producers that don't set one in the envelope
+	if event.ID == "" {
+		event.ID = msg.MessageId
+	}
+
 	// Process the event with timeout
 	processCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"auction/pkg/events"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -77,6 +78,9 @@ func (p *RabbitMQPublisher) DeclareExchange(exchange string) error {
 func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange string, event *events.Event, headers events.Headers) error {
 	// Ensure exchange exists
 	if err := p.DeclareExchange(exchange); err != nil {
+		if errors.Is(err, amqp.ErrClosed) {
+			return fmt.Errorf("%w: failed to declare exchange: %w", events.ErrBrokerUnavailable, err)
+		}
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
@@ -96,6 +100,7 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange string, event
 	// Create the message
 	msg := amqp.Publishing{
 		ContentType:  "application/json",
+		MessageId:    event.ID,
 		Body:         body,
 		DeliveryMode: amqp.Persistent, // Make message persistent
 		Timestamp:    event.Timestamp,
@@ -108,13 +113,13 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange string, event
 	// Create a dedicated channel for this publish operation to avoid confirmation conflicts
 	publishCh, err := p.conn.Channel()
 	if err != nil {
-		return fmt.Errorf("failed to create publish channel: %w", err)
+		return fmt.Errorf("%w: failed to create publish channel: %w", events.ErrBrokerUnavailable, err)
 	}
 	defer publishCh.Close()
 
 	// Enable confirms on this channel
 	if err := publishCh.Confirm(false); err != nil {
-		return fmt.Errorf("failed to enable confirms: %w", err)
+		return fmt.Errorf("%w: failed to enable confirms: %w", events.ErrBrokerUnavailable, err)
 	}
 
 	// Register for confirmations BEFORE publishing
@@ -133,7 +138,7 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange string, event
 		false,      // immediate
 		msg,
 	); err != nil {
-		return fmt.Errorf("failed to publish message: %w", err)
+		return fmt.Errorf("%w: failed to publish message: %w", events.ErrBrokerUnavailable, err)
 	}
 
 	// Wait for confirmation
@@ -143,7 +148,7 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange string, event
 			return fmt.Errorf("message was not acknowledged by broker")
 		}
 	case <-publishCtx.Done():
-		return fmt.Errorf("publish confirmation timeout")
+		return fmt.Errorf("%w: publish confirmation timeout", events.ErrBrokerUnavailable)
 	}
 
 	zap.L().Info("Event published successfully",
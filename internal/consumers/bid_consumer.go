@@ -2,26 +2,27 @@ package consumers
 
 import (
 	"auction/app"
+	"auction/domain"
 	"auction/pkg/events"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
 type BidEventHandler struct {
-	repository app.Repository
-	logger     *zap.Logger
+	repository     app.Repository
+	eventPublisher events.Publisher
+	logger         *zap.Logger
 }
 
-func NewBidEventHandler(repository app.Repository, logger *zap.Logger) *BidEventHandler {
+func NewBidEventHandler(repository app.Repository, eventPublisher events.Publisher, logger *zap.Logger) *BidEventHandler {
 	return &BidEventHandler{
-		repository: repository,
-		logger:     logger,
+		repository:     repository,
+		eventPublisher: eventPublisher,
+		logger:         logger,
 	}
 }
 
@@ -33,48 +34,92 @@ func (h *BidEventHandler) HandleEvent(ctx context.Context, event *events.Event)
 		zap.Any("payload", event.Payload),
 	)
 
-	switch event.Event {
-	case "bid.placed":
-		return h.handleBidPlaced(ctx, event)
-	case "bid.won":
-		return h.handleBidWon(ctx, event)
-	default:
-		zap.L().Warn("Unknown bid event type", zap.String("event", event.Event))
+	payload, err := events.DecodeBidPayload(event)
+	if errors.Is(err, events.ErrUnknownEvent) {
+		zap.L().Warn("Unknown bid event type",
+			zap.String("event", event.Event),
+			zap.String("version", event.Version),
+		)
 		return nil
 	}
-}
-
-func (h *BidEventHandler) handleBidPlaced(ctx context.Context, event *events.Event) error {
-	payloadBytes, err := json.Marshal(event.Payload)
 	if err != nil {
-		return fmt.Errorf("malformed payload - marshal failed: %w", err)
+		return err
 	}
 
-	var payload map[string]any
-	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
-		return fmt.Errorf("malformed payload - unmarshal failed: %w", err)
+	switch p := payload.(type) {
+	case *events.BidPlacedPayload:
+		return h.handleOnce(ctx, event, p.BidID, func(ctx context.Context) error {
+			return h.handleBidPlaced(ctx, event, p)
+		})
+	case *events.BidWonPayload:
+		return h.handleOnce(ctx, event, p.BidID, func(ctx context.Context) error {
+			return h.handleBidWon(ctx, event, p)
+		})
+	case *events.BidCancelledPayload:
+		return h.handleOnce(ctx, event, p.BidID, func(ctx context.Context) error {
+			return h.handleBidCancelled(ctx, event, p)
+		})
+	default:
+		return fmt.Errorf("unhandled payload type %T for %s", payload, event.GetRoutingKey())
 	}
+}
 
-	itemID, ok := payload["ItemID"].(string)
-	if !ok || itemID == "" {
-		return fmt.Errorf("malformed payload - itemId missing or invalid")
+// handleOnce runs handle in a transaction together with the processed_events
+// insert, so a redelivered event is acknowledged without being applied twice.
+func (h *BidEventHandler) handleOnce(ctx context.Context, event *events.Event, bidID string, handle func(ctx context.Context) error) error {
+	key := processedEventKey(event, bidID)
+	if key == "" {
+		zap.L().Warn("Bid event has no ID, duplicates cannot be detected",
+			zap.String("event", event.Event),
+			zap.String("traceId", event.TraceID),
+		)
+		return handle(ctx)
 	}
 
-	amountStr, ok := payload["Amount"].(string)
-	if !ok || amountStr == "" {
-		return fmt.Errorf("malformed payload - amount missing or invalid")
+	return h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		first, err := h.repository.MarkEventProcessed(ctx, key, event.Event)
+		if err != nil {
+			return fmt.Errorf("failed to record processed event: %w", err)
+		}
+
+		if !first {
+			zap.L().Info("Skipping already processed bid event",
+				zap.String("event", event.Event),
+				zap.String("eventKey", key),
+				zap.String("traceId", event.TraceID),
+			)
+			return nil
+		}
+
+		return handle(ctx)
+	})
+}
+
+// processedEventKey identifies an event in the processed_events ledger: the
+// envelope ID when present, otherwise the event name and bid ID.
+func processedEventKey(event *events.Event, bidID string) string {
+	if event.ID != "" {
+		return event.ID
+	}
+
+	if bidID == "" {
+		return ""
 	}
 
+	return event.Event + ":" + bidID
+}
+
+func (h *BidEventHandler) handleBidPlaced(ctx context.Context, event *events.Event, payload *events.BidPlacedPayload) error {
+	itemID := payload.ItemID
+
 	bidTime := event.Timestamp
-	if bidTimeStr, ok := payload["Timestamp"].(string); ok {
-		if parsedTime, err := time.Parse(time.RFC3339, bidTimeStr); err == nil {
-			bidTime = parsedTime
-		}
+	if payload.Timestamp != nil {
+		bidTime = *payload.Timestamp
 	}
 
 	zap.L().Info("Processing bid.placed event",
 		zap.String("itemId", itemID),
-		zap.String("amount", amountStr),
+		zap.String("amount", payload.Amount.String()),
 		zap.Time("bidTime", bidTime),
 		zap.String("traceId", event.TraceID),
 	)
@@ -86,11 +131,27 @@ func (h *BidEventHandler) handleBidPlaced(ctx context.Context, event *events.Eve
 			return fmt.Errorf("failed to get item: %w", err)
 		}
 
-		item.CurrentPrice, err = decimal.NewFromString(amountStr)
-		if err != nil {
-			return fmt.Errorf("malformed payload - invalid amount format: %w", err)
+		if err := item.ValidateBid(payload.Amount, bidTime); err != nil {
+			var rejection *domain.BidRejectionError
+			if !errors.As(err, &rejection) {
+				return err
+			}
+
+			zap.L().Warn("Bid rejected",
+				zap.String("itemId", itemID),
+				zap.String("bidId", payload.BidID),
+				zap.String("amount", payload.Amount.String()),
+				zap.String("reason", string(rejection.Reason)),
+				zap.String("traceId", event.TraceID),
+			)
+
+			return h.publishBidRejected(ctx, event, item, payload, rejection)
 		}
 
+		item.CurrentPrice = payload.Amount
+		item.HighBidderID = &payload.UserID
+		item.BidCount++
+
 		originalEndDate := item.EndDate
 		if item.ShouldExtendForBid(bidTime) {
 			item.EndDate = item.CalculateNewEndDate()
@@ -107,7 +168,7 @@ func (h *BidEventHandler) handleBidPlaced(ctx context.Context, event *events.Eve
 		item.UpdatedAt = time.Now()
 
 		if err := h.repository.Update(ctx, item); err != nil {
-			if strings.Contains(err.Error(), "optimistic lock failed") {
+			if errors.Is(err, app.ErrOptimisticLock) {
 				if attempt < maxRetries {
 					zap.L().Warn("Optimistic lock conflict, retrying",
 						zap.String("itemId", itemID),
@@ -136,37 +197,58 @@ func (h *BidEventHandler) handleBidPlaced(ctx context.Context, event *events.Eve
 	return fmt.Errorf("unexpected error: max retries reached")
 }
 
-func (h *BidEventHandler) handleBidWon(ctx context.Context, event *events.Event) error {
-	payloadBytes, err := json.Marshal(event.Payload)
-	if err != nil {
-		return fmt.Errorf("malformed payload - marshal failed: %w", err)
+// publishBidRejected tells the bid service a bid was not applied so it can
+// refund or notify the bidder
+func (h *BidEventHandler) publishBidRejected(ctx context.Context, event *events.Event, item domain.Item, payload *events.BidPlacedPayload, rejection *domain.BidRejectionError) error {
+	if h.eventPublisher == nil {
+		return nil
 	}
 
-	var payload map[string]interface{}
-	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
-		return fmt.Errorf("malformed payload - unmarshal failed: %w", err)
+	currencyCode := payload.CurrencyCode
+	if currencyCode == "" {
+		currencyCode = item.CurrencyCode
 	}
 
-	// Validate required fields
-	itemID, ok := payload["ItemID"].(string)
-	if !ok || itemID == "" {
-		return fmt.Errorf("malformed payload - itemId missing or invalid")
+	eventPayload := events.ItemBidRejectedPayload{
+		BidID:        payload.BidID,
+		ItemID:       item.ID,
+		UserID:       payload.UserID,
+		Amount:       payload.Amount,
+		CurrencyCode: currencyCode,
+		Reason:       string(rejection.Reason),
+		CurrentPrice: item.CurrentPrice,
+		MinimumBid:   rejection.MinimumBid,
+		RejectedAt:   time.Now().UTC(),
 	}
 
-	buyerID, ok := payload["BuyerID"].(string)
-	if !ok || buyerID == "" {
-		return fmt.Errorf("malformed payload - buyerId missing or invalid")
+	headers := events.Headers{
+		TraceID:       event.TraceID,
+		CorrelationID: event.CorrelationID,
+		Service:       "auction",
 	}
 
-	finalAmount, ok := payload["FinalAmount"].(string)
-	if !ok || finalAmount == "" {
-		return fmt.Errorf("malformed payload - finalAmount missing or invalid")
+	rejectedEvent := events.NewEvent(
+		events.ItemBidRejectedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, rejectedEvent, headers); err != nil {
+		return fmt.Errorf("failed to publish item.bid.rejected event: %w", err)
 	}
 
+	return nil
+}
+
+func (h *BidEventHandler) handleBidWon(ctx context.Context, event *events.Event, payload *events.BidWonPayload) error {
+	itemID := payload.ItemID
+	buyerID := payload.BuyerID
+
 	zap.L().Info("Processing bid.won event",
 		zap.String("itemId", itemID),
 		zap.String("buyerId", buyerID),
-		zap.String("finalAmount", finalAmount),
+		zap.String("finalAmount", payload.FinalAmount.String()),
 		zap.String("traceId", event.TraceID),
 	)
 
@@ -175,13 +257,34 @@ func (h *BidEventHandler) handleBidWon(ctx context.Context, event *events.Event)
 		return fmt.Errorf("failed to get item: %w", err)
 	}
 
-	item.Status = "sold"
-	item.BuyerID = &buyerID
+	// The auction closer may already have settled the item; a sold item only
+	// gets its buyer recorded and an unsold one is reconciled to sold. A win
+	// for an item that cannot be sold is acknowledged, retrying never helps.
+	previousStatus := item.Status
+	if err := item.SettleWon(); err != nil {
+		var transitionErr *domain.TransitionError
+		if errors.As(err, &transitionErr) {
+			zap.L().Warn("Ignoring bid.won for an item that cannot be sold",
+				zap.String("itemId", itemID),
+				zap.String("buyerId", buyerID),
+				zap.String("status", string(previousStatus)),
+				zap.String("traceId", event.TraceID),
+			)
+			return nil
+		}
 
-	finalPrice, err := decimal.NewFromString(finalAmount)
-	if err != nil {
-		return fmt.Errorf("malformed payload - invalid finalAmount format: %w", err)
+		return fmt.Errorf("cannot mark item as sold: %w", err)
+	}
+	if previousStatus == domain.ItemStatusUnsold {
+		zap.L().Warn("Reconciling unsold item with bid.won",
+			zap.String("itemId", itemID),
+			zap.String("buyerId", buyerID),
+			zap.String("traceId", event.TraceID),
+		)
 	}
+	item.BuyerID = &buyerID
+
+	finalPrice := payload.FinalAmount
 	item.CurrentPrice = finalPrice
 	item.EndPrice = &finalPrice
 	item.UpdatedAt = time.Now()
@@ -190,5 +293,57 @@ func (h *BidEventHandler) handleBidWon(ctx context.Context, event *events.Event)
 		return fmt.Errorf("failed to update item: %w", err)
 	}
 
+	// item.sold was already published by the closer when it settled the sale
+	if previousStatus == domain.ItemStatusSold {
+		return nil
+	}
+
+	return h.publishItemSold(ctx, event, item)
+}
+
+func (h *BidEventHandler) publishItemSold(ctx context.Context, event *events.Event, item domain.Item) error {
+	if h.eventPublisher == nil {
+		return nil
+	}
+
+	eventPayload := events.ItemSoldPayload{
+		ID:           item.ID,
+		SellerID:     item.SellerID,
+		BuyerID:      item.BuyerID,
+		CurrencyCode: item.CurrencyCode,
+		FinalPrice:   item.CurrentPrice,
+		Reason:       events.ItemSoldReasonAuction,
+		SoldAt:       item.UpdatedAt,
+	}
+
+	headers := events.Headers{
+		TraceID:       event.TraceID,
+		CorrelationID: event.CorrelationID,
+		Service:       "auction",
+	}
+
+	soldEvent := events.NewEvent(
+		events.ItemSoldEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, soldEvent, headers); err != nil {
+		return fmt.Errorf("failed to publish item.sold event: %w", err)
+	}
+
+	return nil
+}
+
+// handleBidCancelled only logs the cancellation, the item is left untouched
+func (h *BidEventHandler) handleBidCancelled(ctx context.Context, event *events.Event, payload *events.BidCancelledPayload) error {
+	zap.L().Info("Processing bid.cancelled event",
+		zap.String("itemId", payload.ItemID),
+		zap.String("bidId", payload.BidID),
+		zap.String("reason", payload.Reason),
+		zap.String("traceId", event.TraceID),
+	)
+
 	return nil
 }
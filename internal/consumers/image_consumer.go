@@ -0,0 +1,349 @@
+package consumers
+
+import (
+	"auction/app"
+	"auction/domain"
+	"auction/pkg/aws"
+	"auction/pkg/events"
+	"auction/pkg/imageproc"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ImageStorage reads and writes the objects of item images
+type ImageStorage interface {
+	Download(key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(key string) error
+}
+
+// ImageEventHandler renders the variants of uploaded item images and strips
+// the metadata of the originals
+type ImageEventHandler struct {
+	repository     app.Repository
+	storage        ImageStorage
+	processor      *imageproc.Processor
+	eventPublisher events.Publisher
+	logger         *zap.Logger
+}
+
+func NewImageEventHandler(repository app.Repository, storage ImageStorage, processor *imageproc.Processor, eventPublisher events.Publisher, logger *zap.Logger) *ImageEventHandler {
+	return &ImageEventHandler{
+		repository:     repository,
+		storage:        storage,
+		processor:      processor,
+		eventPublisher: eventPublisher,
+		logger:         logger,
+	}
+}
+
+func (h *ImageEventHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	zap.L().Info("Image event received",
+		zap.String("event", event.Event),
+		zap.String("version", event.Version),
+		zap.String("traceId", event.TraceID),
+	)
+
+	payload, err := events.DecodeItemImageUploadedPayload(event)
+	if errors.Is(err, events.ErrUnknownEvent) {
+		zap.L().Warn("Unknown image event type",
+			zap.String("event", event.Event),
+			zap.String("version", event.Version),
+		)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.handleImageUploaded(ctx, event, payload)
+}
+
+func (h *ImageEventHandler) handleImageUploaded(ctx context.Context, event *events.Event, payload *events.ItemImageUploadedPayload) error {
+	image, err := h.repository.GetItemImage(ctx, payload.ItemID, payload.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		zap.L().Info("Skipping deleted image",
+			zap.String("itemId", payload.ItemID),
+			zap.String("imageId", payload.ID),
+			zap.String("traceId", event.TraceID),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	// A redelivered event finds the image already processed
+	stored, err := h.repository.GetItemImageVariants(ctx, []string{image.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get image variants: %w", err)
+	}
+	if h.isProcessed(stored) {
+		zap.L().Info("Skipping already processed image",
+			zap.String("imageId", image.ID),
+			zap.String("traceId", event.TraceID),
+		)
+		return nil
+	}
+
+	key := aws.ObjectKey(image.ImageURL)
+	data, err := h.storage.Download(key)
+	if err != nil {
+		return fmt.Errorf("failed to download image: %w", err)
+	}
+	if data == nil {
+		zap.L().Warn("Image object not found",
+			zap.String("imageId", image.ID),
+			zap.String("key", key),
+			zap.String("traceId", event.TraceID),
+		)
+		return nil
+	}
+
+	result, err := h.processor.Process(data)
+	if errors.Is(err, imageproc.ErrUnsupportedFormat) || errors.Is(err, imageproc.ErrImageTooLarge) {
+		// Retrying cannot help, the image is served without renditions
+		zap.L().Warn("Image cannot be processed",
+			zap.String("imageId", image.ID),
+			zap.String("traceId", event.TraceID),
+			zap.Error(err),
+		)
+		return h.stripOriginal(ctx, event, image, key, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to process image: %w", err)
+	}
+
+	zap.L().Info("Processing item.image.uploaded event",
+		zap.String("itemId", image.ItemID),
+		zap.String("imageId", image.ID),
+		zap.String("format", string(result.Format)),
+		zap.Int("variants", len(result.Variants)),
+		zap.String("traceId", event.TraceID),
+	)
+
+	// Objects are written before taking the images lock, which only covers
+	// checking that the image still exists and saving the rows. When a
+	// concurrent delete removed the image meanwhile, its objects are removed
+	// again; a delete running later finds the rows.
+	written, rendered, err := h.storeResult(ctx, image, key, result)
+	if err != nil {
+		h.deleteObjects(event, orphanedKeys(written, key, stored))
+		return err
+	}
+
+	exists, err := h.withImageLocked(ctx, image, func(ctx context.Context) error {
+		saved, err := h.repository.SaveItemImageVariants(ctx, rendered)
+		if err != nil {
+			return fmt.Errorf("failed to save image variants: %w", err)
+		}
+
+		return h.publishImageProcessed(ctx, event, image, saved)
+	})
+	if err != nil {
+		h.deleteObjects(event, orphanedKeys(written, key, stored))
+		return err
+	}
+	if !exists {
+		h.deleteObjects(event, written)
+	}
+
+	return nil
+}
+
+// storeResult writes the stripped original in place and the variants of
+// image, and returns the keys it wrote, even on error, with the variant rows
+// to save
+func (h *ImageEventHandler) storeResult(ctx context.Context, image domain.ItemImage, key string, result imageproc.Result) ([]string, []domain.ItemImageVariant, error) {
+	written := make([]string, 0, len(result.Variants)+1)
+
+	if result.Original != nil {
+		if err := h.storage.Put(ctx, key, result.Original, result.Format.ContentType()); err != nil {
+			return written, nil, fmt.Errorf("failed to store stripped image: %w", err)
+		}
+		written = append(written, key)
+	}
+
+	variants := make([]domain.ItemImageVariant, 0, len(result.Variants))
+	for _, variant := range result.Variants {
+		variantKey := variantObjectKey(image, variant)
+		if err := h.storage.Put(ctx, variantKey, variant.Data, variant.Format.ContentType()); err != nil {
+			return written, nil, fmt.Errorf("failed to store %s %s variant: %w", variant.Rendition, variant.Format, err)
+		}
+		written = append(written, variantKey)
+
+		variants = append(variants, domain.ItemImageVariant{
+			ImageID:  image.ID,
+			Size:     variant.Rendition,
+			Format:   string(variant.Format),
+			ImageURL: aws.ObjectURL(variantKey),
+			Width:    variant.Width,
+			Height:   variant.Height,
+			Bytes:    int64(len(variant.Data)),
+		})
+	}
+
+	return written, variants, nil
+}
+
+// withImageLocked runs fn holding the images lock of the item when image
+// still exists, and reports whether it does
+func (h *ImageEventHandler) withImageLocked(ctx context.Context, image domain.ItemImage, fn func(ctx context.Context) error) (bool, error) {
+	exists := false
+	err := h.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repository.LockItemImages(ctx, image.ItemID); err != nil {
+			return err
+		}
+
+		_, err := h.repository.GetItemImage(ctx, image.ItemID, image.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		exists = true
+
+		return fn(ctx)
+	})
+
+	return exists, err
+}
+
+// orphanedKeys returns the keys of written that nothing refers to once
+// storing failed: the original and the variants already stored stay
+func orphanedKeys(written []string, original string, stored []domain.ItemImageVariant) []string {
+	kept := map[string]bool{original: true}
+	for _, variant := range stored {
+		kept[aws.ObjectKey(variant.ImageURL)] = true
+	}
+
+	orphaned := make([]string, 0, len(written))
+	for _, key := range written {
+		if !kept[key] {
+			orphaned = append(orphaned, key)
+		}
+	}
+
+	return orphaned
+}
+
+// deleteObjects removes objects left behind. Failures are only logged, the
+// outcome of the event does not depend on them.
+func (h *ImageEventHandler) deleteObjects(event *events.Event, keys []string) {
+	for _, key := range keys {
+		if err := h.storage.Delete(key); err != nil {
+			zap.L().Warn("Failed to delete orphaned image object",
+				zap.String("key", key),
+				zap.String("traceId", event.TraceID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// stripOriginal removes the metadata of an original that has no renditions,
+// which needs no decoding. Originals that cannot be stripped are left as
+// uploaded.
+func (h *ImageEventHandler) stripOriginal(ctx context.Context, event *events.Event, image domain.ItemImage, key string, data []byte) error {
+	stripped, format, err := imageproc.Strip(data)
+	if err != nil {
+		zap.L().Warn("Image metadata cannot be stripped",
+			zap.String("imageId", image.ID),
+			zap.String("traceId", event.TraceID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if stripped == nil {
+		return nil
+	}
+
+	if err := h.storage.Put(ctx, key, stripped, format.ContentType()); err != nil {
+		return fmt.Errorf("failed to store stripped image: %w", err)
+	}
+
+	// A delete that ran meanwhile may have missed the object written back
+	exists, err := h.withImageLocked(ctx, image, func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		h.deleteObjects(event, []string{key})
+	}
+
+	return nil
+}
+
+// isProcessed reports whether variants has every rendition in every format
+func (h *ImageEventHandler) isProcessed(variants []domain.ItemImageVariant) bool {
+	stored := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		stored[variant.Size+"."+variant.Format] = true
+	}
+
+	for _, rendition := range h.processor.Renditions() {
+		for _, format := range h.processor.Formats() {
+			if !stored[rendition.Name+"."+string(format)] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// variantObjectKey stores variants next to each other as
+// items/{itemId}/{imageId}/{size}.{ext}
+func variantObjectKey(image domain.ItemImage, variant imageproc.Variant) string {
+	return path.Join("items", image.ItemID, image.ID, variant.Rendition+variant.Format.Extension())
+}
+
+func (h *ImageEventHandler) publishImageProcessed(ctx context.Context, event *events.Event, image domain.ItemImage, variants []domain.ItemImageVariant) error {
+	if h.eventPublisher == nil {
+		return nil
+	}
+
+	eventPayload := events.ItemImageProcessedPayload{
+		ID:          image.ID,
+		ItemID:      image.ItemID,
+		Variants:    make([]events.ItemImageVariant, 0, len(variants)),
+		ProcessedAt: time.Now().UTC(),
+	}
+	for _, variant := range variants {
+		eventPayload.Variants = append(eventPayload.Variants, events.ItemImageVariant{
+			Size:     variant.Size,
+			Format:   variant.Format,
+			ImageURL: variant.ImageURL,
+			Width:    variant.Width,
+			Height:   variant.Height,
+		})
+	}
+
+	headers := events.Headers{
+		TraceID:       event.TraceID,
+		CorrelationID: event.CorrelationID,
+		Service:       "auction",
+	}
+
+	processedEvent := events.NewEvent(
+		events.ItemImageProcessedEvent,
+		events.EventVersionV1,
+		eventPayload,
+		headers,
+	)
+
+	if err := h.eventPublisher.Publish(ctx, events.ItemExchange, processedEvent, headers); err != nil {
+		return fmt.Errorf("failed to publish item.image.processed event: %w", err)
+	}
+
+	return nil
+}
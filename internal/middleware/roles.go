@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"auction/pkg/httperror"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewRequireRoleMiddleware only lets requests through whose comma separated
+// User-Roles header contains role. It runs after the security headers
+// middleware and adds the roles to the user context.
+func NewRequireRoleMiddleware(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roles := parseRoles(c.Get("User-Roles"))
+
+		if !slices.Contains(roles, role) {
+			return forbidden(c)
+		}
+
+		userCtx := c.UserContext()
+		if userCtx == nil {
+			userCtx = context.Background()
+		}
+
+		userCtx = context.WithValue(userCtx, "UserRoles", roles)
+
+		c.SetUserContext(userCtx)
+		return c.Next()
+	}
+}
+
+func parseRoles(header string) []string {
+	roles := make([]string, 0)
+	for _, role := range strings.Split(header, ",") {
+		if role = strings.ToLower(strings.TrimSpace(role)); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+func forbidden(c *fiber.Ctx) error {
+	err := httperror.Forbidden(
+		"auction.roles.forbidden",
+		"Missing required role",
+		nil,
+	)
+
+	return c.Status(err.Status).JSON(fiber.Map{
+		"code":    err.Code,
+		"message": err.Message,
+	})
+}
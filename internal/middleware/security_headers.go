@@ -10,28 +10,50 @@ import (
 
 func NewSecurityHeadersMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userID := strings.TrimSpace(c.Get("User-ID"))
-		userEmail := strings.TrimSpace(c.Get("User-Email"))
-		authorization := strings.TrimSpace(c.Get("Authorization"))
-
-		if userID == "" || userEmail == "" || authorization == "" {
+		if !setUserContext(c) {
 			return unauthorized(c)
 		}
 
-		userCtx := c.UserContext()
-		if userCtx == nil {
-			userCtx = context.Background()
-		}
+		return c.Next()
+	}
+}
 
-		userCtx = context.WithValue(userCtx, "UserID", userID)
-		userCtx = context.WithValue(userCtx, "UserEmail", userEmail)
-		userCtx = context.WithValue(userCtx, "Jwt", authorization)
+// NewOptionalSecurityHeadersMiddleware identifies the user on public routes
+// that show more to signed in users. Requests without the security headers
+// pass through anonymously.
+func NewOptionalSecurityHeadersMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		setUserContext(c)
 
-		c.SetUserContext(userCtx)
 		return c.Next()
 	}
 }
 
+// setUserContext stores the user of the security headers in the user context.
+// It reports false, leaving the context as is, when a header is missing.
+func setUserContext(c *fiber.Ctx) bool {
+	userID := strings.TrimSpace(c.Get("User-ID"))
+	userEmail := strings.TrimSpace(c.Get("User-Email"))
+	authorization := strings.TrimSpace(c.Get("Authorization"))
+
+	if userID == "" || userEmail == "" || authorization == "" {
+		return false
+	}
+
+	userCtx := c.UserContext()
+	if userCtx == nil {
+		userCtx = context.Background()
+	}
+
+	userCtx = context.WithValue(userCtx, "UserID", userID)
+	userCtx = context.WithValue(userCtx, "UserEmail", userEmail)
+	userCtx = context.WithValue(userCtx, "Jwt", authorization)
+	userCtx = context.WithValue(userCtx, "UserRoles", parseRoles(c.Get("User-Roles")))
+
+	c.SetUserContext(userCtx)
+	return true
+}
+
 func unauthorized(c *fiber.Ctx) error {
 	err := httperror.Unauthorized(
 		"auction.security_headers.unauthorized",
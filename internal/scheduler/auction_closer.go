@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"auction/app"
+	"auction/domain"
+	"auction/pkg/events"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AuctionCloser ends active items whose end date has passed and settles them as
+// sold or unsold.
+// Items are claimed with FOR UPDATE SKIP LOCKED, so several worker replicas can
+// run it at the same time without closing the same item twice.
+type AuctionCloser struct {
+	repository app.Repository
+	publisher  events.Publisher
+	batchSize  int
+}
+
+func NewAuctionCloser(repository app.Repository, publisher events.Publisher, batchSize int) *AuctionCloser {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &AuctionCloser{
+		repository: repository,
+		publisher:  publisher,
+		batchSize:  batchSize,
+	}
+}
+
+func (c *AuctionCloser) Name() string {
+	return "auction-closer"
+}
+
+// Run closes expired items batch by batch until none are left
+func (c *AuctionCloser) Run(ctx context.Context) error {
+	for {
+		closed, err := c.closeBatch(ctx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if len(closed) < c.batchSize {
+			return nil
+		}
+	}
+}
+
+func (c *AuctionCloser) closeBatch(ctx context.Context, now time.Time) ([]domain.Item, error) {
+	closed := make([]domain.Item, 0)
+
+	err := c.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := c.repository.ClaimExpiredItems(ctx, now, c.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim expired items: %w", err)
+		}
+
+		for _, item := range items {
+			if err := item.Close(); err != nil {
+				return fmt.Errorf("failed to close item %s: %w", item.ID, err)
+			}
+			if item.Status == domain.ItemStatusSold {
+				finalPrice := item.CurrentPrice
+				item.EndPrice = &finalPrice
+			}
+			item.UpdatedAt = now
+
+			if err := c.repository.Update(ctx, item); err != nil {
+				return fmt.Errorf("failed to close item %s: %w", item.ID, err)
+			}
+			item.Version++
+
+			if err := c.publishEvents(ctx, item); err != nil {
+				return err
+			}
+
+			closed = append(closed, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(closed) > 0 {
+		zap.L().Info("Closed expired auctions", zap.Int("count", len(closed)))
+	}
+
+	return closed, nil
+}
+
+func (c *AuctionCloser) publishEvents(ctx context.Context, item domain.Item) error {
+	err := publishItemEvent(ctx, c.publisher, events.ItemEndedEvent, events.ItemEndedPayload{
+		ID:           item.ID,
+		SellerID:     item.SellerID,
+		Status:       string(item.Status),
+		CurrencyCode: item.CurrencyCode,
+		FinalPrice:   item.EndPrice,
+		BidCount:     item.BidCount,
+		EndedAt:      item.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if item.Status == domain.ItemStatusSold {
+		return publishItemEvent(ctx, c.publisher, events.ItemSoldEvent, events.ItemSoldPayload{
+			ID:           item.ID,
+			SellerID:     item.SellerID,
+			BuyerID:      item.BuyerID,
+			CurrencyCode: item.CurrencyCode,
+			FinalPrice:   item.CurrentPrice,
+			Reason:       events.ItemSoldReasonAuction,
+			SoldAt:       item.UpdatedAt,
+		})
+	}
+
+	reason := "reserve_not_met"
+	if !item.HasBids() {
+		reason = "no_bids"
+	}
+
+	return publishItemEvent(ctx, c.publisher, events.ItemUnsoldEvent, events.ItemUnsoldPayload{
+		ID:           item.ID,
+		SellerID:     item.SellerID,
+		Reason:       reason,
+		CurrencyCode: item.CurrencyCode,
+		HighestBid:   item.CurrentPrice,
+		EndedAt:      item.UpdatedAt,
+	})
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"auction/app"
+	"auction/domain"
+	"auction/pkg/events"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AuctionStarter activates scheduled items once their start date arrives.
+// Like AuctionCloser it claims rows with FOR UPDATE SKIP LOCKED and is safe to
+// run on every worker replica.
+type AuctionStarter struct {
+	repository app.Repository
+	publisher  events.Publisher
+	batchSize  int
+}
+
+func NewAuctionStarter(repository app.Repository, publisher events.Publisher, batchSize int) *AuctionStarter {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &AuctionStarter{
+		repository: repository,
+		publisher:  publisher,
+		batchSize:  batchSize,
+	}
+}
+
+func (s *AuctionStarter) Name() string {
+	return "auction-starter"
+}
+
+// Run activates scheduled items batch by batch until none are left
+func (s *AuctionStarter) Run(ctx context.Context) error {
+	for {
+		started, err := s.startBatch(ctx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if len(started) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *AuctionStarter) startBatch(ctx context.Context, now time.Time) ([]domain.Item, error) {
+	started := make([]domain.Item, 0)
+
+	err := s.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := s.repository.ClaimScheduledItems(ctx, now, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim scheduled items: %w", err)
+		}
+
+		for _, item := range items {
+			if err := item.TransitionTo(domain.ItemStatusActive); err != nil {
+				return fmt.Errorf("failed to start item %s: %w", item.ID, err)
+			}
+			item.UpdatedAt = now
+
+			if err := s.repository.Update(ctx, item); err != nil {
+				return fmt.Errorf("failed to start item %s: %w", item.ID, err)
+			}
+			item.Version++
+
+			err := publishItemEvent(ctx, s.publisher, events.ItemStartedEvent, events.ItemStartedPayload{
+				ID:           item.ID,
+				SellerID:     item.SellerID,
+				CurrencyCode: item.CurrencyCode,
+				StartPrice:   item.StartPrice,
+				StartDate:    item.StartDate,
+				EndDate:      item.EndDate,
+				StartedAt:    item.UpdatedAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			started = append(started, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(started) > 0 {
+		zap.L().Info("Started scheduled auctions", zap.Int("count", len(started)))
+	}
+
+	return started, nil
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"auction/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ImageUploadRepository is the storage used by ImageUploadSweeper
+type ImageUploadRepository interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	ClaimExpiredImageUploads(ctx context.Context, before time.Time, limit int) ([]domain.ItemImageUpload, error)
+	DeleteImageUpload(ctx context.Context, uploadID string) error
+}
+
+// ObjectStorage deletes uploaded objects
+type ObjectStorage interface {
+	Delete(key string) error
+}
+
+// ImageUploadSweeper deletes presigned image uploads that were never
+// completed, along with whatever was uploaded for them. Uploads are only swept
+// once they have been expired for the grace period, so requests that started
+// before the URL expired can finish.
+type ImageUploadSweeper struct {
+	repository ImageUploadRepository
+	storage    ObjectStorage
+	batchSize  int
+	grace      time.Duration
+}
+
+func NewImageUploadSweeper(repository ImageUploadRepository, storage ObjectStorage, batchSize int, grace time.Duration) *ImageUploadSweeper {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &ImageUploadSweeper{
+		repository: repository,
+		storage:    storage,
+		batchSize:  batchSize,
+		grace:      grace,
+	}
+}
+
+func (s *ImageUploadSweeper) Name() string {
+	return "image-upload-sweeper"
+}
+
+// Run sweeps stale uploads batch by batch until none are left
+func (s *ImageUploadSweeper) Run(ctx context.Context) error {
+	for {
+		swept, err := s.sweepBatch(ctx, time.Now().UTC().Add(-s.grace))
+		if err != nil {
+			return err
+		}
+
+		if swept < s.batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *ImageUploadSweeper) sweepBatch(ctx context.Context, before time.Time) (int, error) {
+	var swept int
+
+	err := s.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		uploads, err := s.repository.ClaimExpiredImageUploads(ctx, before, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim expired image uploads: %w", err)
+		}
+
+		for _, upload := range uploads {
+			// Deleting a missing object succeeds, so a rolled back batch is simply swept again
+			if err := s.storage.Delete(upload.ObjectKey); err != nil {
+				return fmt.Errorf("failed to delete object of image upload %s: %w", upload.ID, err)
+			}
+
+			if err := s.repository.DeleteImageUpload(ctx, upload.ID); err != nil {
+				return fmt.Errorf("failed to delete image upload %s: %w", upload.ID, err)
+			}
+			swept++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if swept > 0 {
+		zap.L().Info("Swept stale image uploads", zap.Int("count", swept))
+	}
+
+	return swept, nil
+}
@@ -0,0 +1,242 @@
+package scheduler
+
+import (
+	"auction/pkg/events"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// OutboxRepository is the storage used by OutboxRelay
+type OutboxRepository interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxMessage, error)
+	MarkOutboxMessagePublished(ctx context.Context, id string) error
+	MarkOutboxMessageFailed(ctx context.Context, id string, reason string, park bool) error
+	ReleaseOutboxMessages(ctx context.Context, ids []string) error
+	DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxRelay publishes pending outbox messages to RabbitMQ and marks them as
+// sent. A message is only marked after the broker confirmed it, which gives
+// at-least-once delivery: consumers must tolerate duplicates.
+//
+// Messages that are malformed or fail maxAttempts times while the broker is
+// reachable are parked, so they do not hold back the messages behind them.
+type OutboxRelay struct {
+	repository  OutboxRepository
+	publisher   events.Publisher
+	batchSize   int
+	maxAttempts int
+	retention   time.Duration
+	lastPruned  time.Time
+}
+
+// outboxLease is how long a claimed batch is reserved for the relay that
+// claimed it. Publishing a batch takes far less; messages of a relay that
+// stopped are claimed again once it expires.
+const outboxLease = time.Minute
+
+// errMalformedMessage is returned by relay for messages that can never be
+// published
+var errMalformedMessage = errors.New("malformed outbox payload")
+
+func NewOutboxRelay(repository OutboxRepository, publisher events.Publisher, batchSize int, maxAttempts int, retention time.Duration) *OutboxRelay {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+
+	return &OutboxRelay{
+		repository:  repository,
+		publisher:   publisher,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+	}
+}
+
+func (r *OutboxRelay) Name() string {
+	return "outbox-relay"
+}
+
+// Run relays pending messages batch by batch until none are left
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	for {
+		done, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Messages to retry are left for the next run rather than retried at once
+		if done < r.batchSize {
+			break
+		}
+	}
+
+	return r.prune(ctx)
+}
+
+// relayBatch relays one batch of messages and returns how many were relayed
+// or parked. It stops at the first message the broker could not be reached
+// for, releasing the remaining messages.
+//
+// The batch is leased rather than locked, so no transaction is open while the
+// relay waits for the broker. The outcomes are recorded together afterwards.
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	messages, err := r.repository.ClaimOutboxMessages(ctx, r.batchSize, outboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	claimedAt := time.Now()
+
+	outcomes := make([]outboxOutcome, 0, len(messages))
+	unpublished := make([]string, 0)
+	var publishErr error
+
+	for i, message := range messages {
+		// Past the lease another relay may claim the rest, leave it to the next run
+		if time.Since(claimedAt) >= outboxLease {
+			unpublished = outboxMessageIDs(messages[i:])
+			break
+		}
+
+		err := r.relay(ctx, message)
+		if err == nil {
+			outcomes = append(outcomes, outboxOutcome{message: message})
+			continue
+		}
+
+		if errors.Is(err, events.ErrBrokerUnavailable) || !r.brokerHealthy() {
+			// The attempt is not counted, the message did nothing wrong
+			publishErr = fmt.Errorf("failed to relay outbox message %s: %w", message.ID, err)
+			unpublished = outboxMessageIDs(messages[i:])
+			break
+		}
+
+		park := errors.Is(err, errMalformedMessage) || message.Attempts+1 >= r.maxAttempts
+		outcomes = append(outcomes, outboxOutcome{message: message, err: err, park: park})
+	}
+
+	if err := r.record(ctx, outcomes, unpublished); err != nil {
+		return 0, err
+	}
+
+	var relayed, parked int
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.err == nil:
+			relayed++
+		case outcome.park:
+			parked++
+			zap.L().Error("Parked outbox message",
+				zap.String("id", outcome.message.ID),
+				zap.String("event", outcome.message.EventName),
+				zap.Int("attempts", outcome.message.Attempts+1),
+				zap.Error(outcome.err),
+			)
+		default:
+			zap.L().Warn("Failed to relay outbox message",
+				zap.String("id", outcome.message.ID),
+				zap.String("event", outcome.message.EventName),
+				zap.Int("attempts", outcome.message.Attempts+1),
+				zap.Error(outcome.err),
+			)
+		}
+	}
+
+	if relayed > 0 {
+		zap.L().Info("Relayed outbox messages", zap.Int("count", relayed), zap.Int("parked", parked))
+	}
+
+	return relayed + parked, publishErr
+}
+
+// outboxOutcome is the result of relaying a claimed message: published when
+// err is nil, failed otherwise
+type outboxOutcome struct {
+	message events.OutboxMessage
+	err     error
+	park    bool
+}
+
+// record stores the outcomes of a batch and releases its unpublished messages
+// in one transaction
+func (r *OutboxRelay) record(ctx context.Context, outcomes []outboxOutcome, unpublished []string) error {
+	return r.repository.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, outcome := range outcomes {
+			id := outcome.message.ID
+
+			if outcome.err == nil {
+				if err := r.repository.MarkOutboxMessagePublished(ctx, id); err != nil {
+					return fmt.Errorf("failed to mark outbox message %s as published: %w", id, err)
+				}
+				continue
+			}
+
+			if err := r.repository.MarkOutboxMessageFailed(ctx, id, outcome.err.Error(), outcome.park); err != nil {
+				return fmt.Errorf("failed to mark outbox message %s as failed: %w", id, err)
+			}
+		}
+
+		if len(unpublished) > 0 {
+			if err := r.repository.ReleaseOutboxMessages(ctx, unpublished); err != nil {
+				return fmt.Errorf("failed to release outbox messages: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func outboxMessageIDs(messages []events.OutboxMessage) []string {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+
+	return ids
+}
+
+func (r *OutboxRelay) relay(ctx context.Context, message events.OutboxMessage) error {
+	event, err := events.DecodeEvent(message.Payload)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errMalformedMessage, err)
+	}
+
+	return r.publisher.Publish(ctx, message.Exchange, event, message.Headers())
+}
+
+// brokerHealthy reports whether the publisher is still connected, for
+// publishers that can tell
+func (r *OutboxRelay) brokerHealthy() bool {
+	if health, ok := r.publisher.(interface{ IsHealthy() bool }); ok {
+		return health.IsHealthy()
+	}
+
+	return true
+}
+
+// prune deletes published messages older than the retention period, at most once an hour
+func (r *OutboxRelay) prune(ctx context.Context) error {
+	if r.retention <= 0 || time.Since(r.lastPruned) < time.Hour {
+		return nil
+	}
+
+	deleted, err := r.repository.DeletePublishedOutboxMessages(ctx, time.Now().UTC().Add(-r.retention))
+	if err != nil {
+		return fmt.Errorf("failed to prune outbox: %w", err)
+	}
+	r.lastPruned = time.Now()
+
+	if deleted > 0 {
+		zap.L().Info("Pruned published outbox messages", zap.Int64("count", deleted))
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"auction/pkg/events"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeOutbox records what the relay does to the messages it claimed
+type fakeOutbox struct {
+	messages  []events.OutboxMessage
+	inTx      bool
+	published []string
+	failed    map[string]bool // Parked when true
+	released  []string
+}
+
+func (o *fakeOutbox) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	o.inTx = true
+	defer func() { o.inTx = false }()
+
+	return fn(ctx)
+}
+
+func (o *fakeOutbox) ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxMessage, error) {
+	return o.messages[:min(limit, len(o.messages))], nil
+}
+
+func (o *fakeOutbox) MarkOutboxMessagePublished(ctx context.Context, id string) error {
+	o.published = append(o.published, id)
+	return nil
+}
+
+func (o *fakeOutbox) MarkOutboxMessageFailed(ctx context.Context, id string, reason string, park bool) error {
+	o.failed[id] = park
+	return nil
+}
+
+func (o *fakeOutbox) ReleaseOutboxMessages(ctx context.Context, ids []string) error {
+	o.released = append(o.released, ids...)
+	return nil
+}
+
+func (o *fakeOutbox) DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeBroker fails the events named in errs and checks that no transaction
+// is open while it publishes
+type fakeBroker struct {
+	t      *testing.T
+	outbox *fakeOutbox
+	errs   map[string]error
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, exchange string, event *events.Event, headers events.Headers) error {
+	if b.outbox.inTx {
+		b.t.Errorf("published %s within a transaction", event.Event)
+	}
+
+	return b.errs[event.Event]
+}
+
+func (b *fakeBroker) Close() error { return nil }
+
+func outboxMessage(t *testing.T, id string, attempts int) events.OutboxMessage {
+	payload, err := events.NewEvent(id, events.EventVersionV1, map[string]string{}, events.Headers{}).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+
+	return events.OutboxMessage{ID: id, EventName: id, Exchange: events.ItemExchange, Payload: payload, Attempts: attempts}
+}
+
+func TestOutboxRelayBatch(t *testing.T) {
+	outbox := &fakeOutbox{
+		messages: []events.OutboxMessage{
+			outboxMessage(t, "first", 0),
+			{ID: "malformed", Payload: []byte("{")},
+			outboxMessage(t, "failing", 0),
+			outboxMessage(t, "exhausted", 2),
+			outboxMessage(t, "last", 0),
+		},
+		failed: make(map[string]bool),
+	}
+	failure := errors.New("unroutable")
+	broker := &fakeBroker{t: t, outbox: outbox, errs: map[string]error{"failing": failure, "exhausted": failure}}
+
+	done, err := NewOutboxRelay(outbox, broker, 10, 3, 0).relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("relayBatch: %v", err)
+	}
+
+	if done != 4 {
+		t.Errorf("done = %d, want 4 relayed or parked", done)
+	}
+	if !slices.Equal(outbox.published, []string{"first", "last"}) {
+		t.Errorf("published = %v, want first, last", outbox.published)
+	}
+	if park, ok := outbox.failed["malformed"]; !ok || !park {
+		t.Errorf("malformed message: failed = %v, %v; want parked", park, ok)
+	}
+	if park, ok := outbox.failed["failing"]; !ok || park {
+		t.Errorf("failing message: failed = %v, %v; want retried", park, ok)
+	}
+	if park, ok := outbox.failed["exhausted"]; !ok || !park {
+		t.Errorf("exhausted message: failed = %v, %v; want parked", park, ok)
+	}
+	if len(outbox.released) != 0 {
+		t.Errorf("released = %v, want none", outbox.released)
+	}
+}
+
+func TestOutboxRelayBrokerUnavailable(t *testing.T) {
+	outbox := &fakeOutbox{
+		messages: []events.OutboxMessage{
+			outboxMessage(t, "first", 0),
+			outboxMessage(t, "second", 0),
+			outboxMessage(t, "third", 0),
+		},
+		failed: make(map[string]bool),
+	}
+	broker := &fakeBroker{t: t, outbox: outbox, errs: map[string]error{"second": events.ErrBrokerUnavailable}}
+
+	done, err := NewOutboxRelay(outbox, broker, 10, 3, 0).relayBatch(context.Background())
+	if !errors.Is(err, events.ErrBrokerUnavailable) {
+		t.Fatalf("err = %v, want %v", err, events.ErrBrokerUnavailable)
+	}
+
+	if done != 1 || !slices.Equal(outbox.published, []string{"first"}) {
+		t.Errorf("done = %d, published = %v; want first only", done, outbox.published)
+	}
+	if len(outbox.failed) != 0 {
+		t.Errorf("failed = %v, want no attempt counted", outbox.failed)
+	}
+	if !slices.Equal(outbox.released, []string{"second", "third"}) {
+		t.Errorf("released = %v, want second, third", outbox.released)
+	}
+}
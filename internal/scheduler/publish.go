@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"auction/pkg/events"
+	"context"
+	"fmt"
+)
+
+// publishItemEvent publishes an item event emitted by a scheduled job
+func publishItemEvent(ctx context.Context, publisher events.Publisher, eventName string, payload any) error {
+	if publisher == nil {
+		return nil
+	}
+
+	headers := events.Headers{
+		TraceID:       events.GenerateTraceID(),
+		CorrelationID: events.GenerateCorrelationID(),
+		Service:       "auction",
+	}
+
+	event := events.NewEvent(
+		eventName,
+		events.EventVersionV1,
+		payload,
+		headers,
+	)
+
+	if err := publisher.Publish(ctx, events.ItemExchange, event, headers); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventName, err)
+	}
+
+	return nil
+}
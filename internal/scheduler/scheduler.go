@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job is a unit of periodic background work run by the Scheduler
+type Job interface {
+	// Name identifies the job in logs
+	Name() string
+
+	// Run performs a single pass of the job
+	Run(ctx context.Context) error
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler runs registered jobs on fixed intervals until its context is cancelled.
+// Jobs must be safe to run concurrently on every worker replica.
+type Scheduler struct {
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job that runs every interval
+func (s *Scheduler) Register(job Job, interval time.Duration) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Start launches one goroutine per registered job
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.run(ctx, e)
+		}(e)
+	}
+}
+
+// Wait blocks until all jobs have stopped
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, e entry) {
+	zap.L().Info("Scheduled job started",
+		zap.String("job", e.job.Name()),
+		zap.Duration("interval", e.interval),
+	)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.job.Run(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Error("Scheduled job failed",
+				zap.String("job", e.job.Name()),
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Scheduled job stopped", zap.String("job", e.job.Name()))
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,17 +2,35 @@ package aws
 
 import (
 	"auction/pkg/config"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/gofiber/storage/s3/v2"
 )
 
 var appConfig = config.Read()
 
+// ErrObjectNotFound is returned by Stat when no object is stored under the key
+var ErrObjectNotFound = errors.New("object not found")
+
 type S3 struct {
 	bucket *s3.Storage
 }
 
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
 func NewS3Bucket() *S3 {
 	s3 := s3.New(s3.Config{
 		Endpoint: appConfig.AWSEndpoint,
@@ -36,6 +54,19 @@ func (s *S3) Upload(key string, data []byte) error {
 	return s.bucket.Set(key, data, time.Hour*100)
 }
 
+// Put stores data under key, served with contentType
+func (s *S3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.bucket.Conn().PutObject(ctx, &awss3.PutObjectInput{
+		Bucket:      aws.String(appConfig.AWSBucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+
+	return err
+}
+
+// Download returns the object under key, or nil when there is none
 func (s *S3) Download(key string) ([]byte, error) {
 	return s.bucket.Get(key)
 }
@@ -43,3 +74,115 @@ func (s *S3) Download(key string) ([]byte, error) {
 func (s *S3) Delete(key string) error {
 	return s.bucket.Delete(key)
 }
+
+// PresignUpload returns a URL that lets clients PUT an object of contentType
+// and exactly size bytes under key until ttl passes. URLs are signed for
+// AWS_PUBLIC_ENDPOINT when it is set, so clients outside the network of the
+// storage can reach it.
+func (s *S3) PresignUpload(ctx context.Context, key string, contentType string, size int64, ttl time.Duration) (string, error) {
+	presigner := awss3.NewPresignClient(s.bucket.Conn(), func(o *awss3.PresignOptions) {
+		if appConfig.AWSPublicEndpoint != "" {
+			o.ClientOptions = append(o.ClientOptions, func(o *awss3.Options) {
+				o.BaseEndpoint = aws.String(appConfig.AWSPublicEndpoint)
+			})
+		}
+	})
+
+	// The Content-Length is signed, so storage refuses bodies of another size
+	request, err := presigner.PresignPutObject(ctx, &awss3.PutObjectInput{
+		Bucket:        aws.String(appConfig.AWSBucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, awss3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+// Copy stores a copy of the object under srcKey under dstKey, served with
+// contentType
+func (s *S3) Copy(ctx context.Context, srcKey string, dstKey string, contentType string) error {
+	_, err := s.bucket.Conn().CopyObject(ctx, &awss3.CopyObjectInput{
+		Bucket:            aws.String(appConfig.AWSBucket),
+		Key:               aws.String(dstKey),
+		CopySource:        aws.String(appConfig.AWSBucket + "/" + srcKey),
+		ContentType:       aws.String(contentType),
+		MetadataDirective: types.MetadataDirectiveReplace,
+	})
+
+	return err
+}
+
+// Stat returns the size and content type of the object under key, or
+// ErrObjectNotFound
+func (s *S3) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	output, err := s.bucket.Conn().HeadObject(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(appConfig.AWSBucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return ObjectInfo{}, ErrObjectNotFound
+		}
+
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{
+		Size:        aws.ToInt64(output.ContentLength),
+		ContentType: aws.ToString(output.ContentType),
+	}, nil
+}
+
+// DownloadHead returns up to the first n bytes of the object under key
+func (s *S3) DownloadHead(ctx context.Context, key string, n int) ([]byte, error) {
+	output, err := s.bucket.Conn().GetObject(ctx, &awss3.GetObjectInput{
+		Bucket: aws.String(appConfig.AWSBucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", n-1)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(io.LimitReader(output.Body, int64(n)))
+}
+
+// ObjectURL returns the public URL of the object under key
+func ObjectURL(key string) string {
+	// For MinIO/S3, construct the public URL
+	// Format: http(s)://endpoint/bucket/key
+	if appConfig.AWSPublicEndpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", appConfig.AWSPublicEndpoint, appConfig.AWSBucket, key)
+	}
+	if appConfig.AWSEndpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", appConfig.AWSEndpoint, appConfig.AWSBucket, key)
+	}
+
+	// For AWS S3, use standard URL format
+	if appConfig.AWSDefaultRegion != "" {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", appConfig.AWSBucket, appConfig.AWSDefaultRegion, key)
+	}
+
+	// Fallback
+	return key
+}
+
+// ObjectKey returns the key of the object at a URL returned by ObjectURL
+func ObjectKey(objectURL string) string {
+	// Images may have been stored before AWS_PUBLIC_ENDPOINT was set
+	for _, endpoint := range []string{appConfig.AWSPublicEndpoint, appConfig.AWSEndpoint} {
+		url := fmt.Sprintf("%s/%s/", endpoint, appConfig.AWSBucket)
+		if endpoint != "" && strings.HasPrefix(objectURL, url) {
+			return strings.TrimPrefix(objectURL, url)
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s/", appConfig.AWSEndpoint, appConfig.AWSBucket)
+	return strings.Replace(objectURL, url, "", 1)
+}
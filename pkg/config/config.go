@@ -2,26 +2,41 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type AppConfig struct {
-	Port             string `mapstructure:"PORT"`
-	PostgresUsername string `mapstructure:"POSTGRES_USERNAME"`
-	PostgresPassword string `mapstructure:"POSTGRES_PASSWORD"`
-	PostgresDatabase string `mapstructure:"POSTGRES_DATABASE"`
-	PostgresSSLMode  string `mapstructure:"POSTGRES_SSLMODE"`
-	PostgresHost     string `mapstructure:"POSTGRES_HOST"`
-	PostgresPort     string `mapstructure:"POSTGRES_PORT"`
-	RabbitMQURL      string `mapstructure:"RABBITMQ_URL"`
-	ServiceName      string `mapstructure:"SERVICE_NAME"`
-	AWSEndpoint      string `mapstructure:"AWS_ENDPOINT"`
-	AWSBucket        string `mapstructure:"AWS_BUCKET"`
-	AWSDefaultRegion string `mapstructure:"AWS_DEFAULT_REGION"`
-	AWSAccessKey     string `mapstructure:"AWS_ACCESS_KEY"`
-	AWSSecretKey     string `mapstructure:"AWS_SECRET_KEY"`
-	GRPCPort         string `mapstructure:"GRPC_PORT"`
+	Port              string `mapstructure:"PORT"`
+	PostgresUsername  string `mapstructure:"POSTGRES_USERNAME"`
+	PostgresPassword  string `mapstructure:"POSTGRES_PASSWORD"`
+	PostgresDatabase  string `mapstructure:"POSTGRES_DATABASE"`
+	PostgresSSLMode   string `mapstructure:"POSTGRES_SSLMODE"`
+	PostgresHost      string `mapstructure:"POSTGRES_HOST"`
+	PostgresPort      string `mapstructure:"POSTGRES_PORT"`
+	RabbitMQURL       string `mapstructure:"RABBITMQ_URL"`
+	ServiceName       string `mapstructure:"SERVICE_NAME"`
+	AWSEndpoint       string `mapstructure:"AWS_ENDPOINT"`
+	AWSPublicEndpoint string `mapstructure:"AWS_PUBLIC_ENDPOINT"`
+	AWSBucket         string `mapstructure:"AWS_BUCKET"`
+	AWSDefaultRegion  string `mapstructure:"AWS_DEFAULT_REGION"`
+	AWSAccessKey      string `mapstructure:"AWS_ACCESS_KEY"`
+	AWSSecretKey      string `mapstructure:"AWS_SECRET_KEY"`
+	GRPCPort          string `mapstructure:"GRPC_PORT"`
+	SearchLanguages   string `mapstructure:"SEARCH_LANGUAGES"`
+
+	CommentEditWindow      time.Duration `mapstructure:"COMMENT_EDIT_WINDOW"`
+	CommentHeldWords       string        `mapstructure:"COMMENT_HELD_WORDS"`
+	CommentReportThreshold int           `mapstructure:"COMMENT_REPORT_THRESHOLD"`
+
+	ItemMaxImages       int           `mapstructure:"ITEM_MAX_IMAGES"`
+	ImageUploadURLTTL   time.Duration `mapstructure:"IMAGE_UPLOAD_URL_TTL"`
+	ImageUploadMaxBytes int64         `mapstructure:"IMAGE_UPLOAD_MAX_BYTES"`
+
+	ImageRenditions       string `mapstructure:"IMAGE_RENDITIONS"`
+	ImageRenditionFormats string `mapstructure:"IMAGE_RENDITION_FORMATS"`
+	ImageQuality          int    `mapstructure:"IMAGE_QUALITY"`
 }
 
 func Read() *AppConfig {
@@ -54,11 +69,22 @@ func bindEnvVariables() {
 	_ = viper.BindEnv("RABBITMQ_URL")
 	_ = viper.BindEnv("SERVICE_NAME")
 	_ = viper.BindEnv("AWS_ENDPOINT")
+	_ = viper.BindEnv("AWS_PUBLIC_ENDPOINT")
 	_ = viper.BindEnv("AWS_BUCKET")
 	_ = viper.BindEnv("AWS_DEFAULT_REGION")
 	_ = viper.BindEnv("AWS_ACCESS_KEY")
 	_ = viper.BindEnv("AWS_SECRET_KEY")
 	_ = viper.BindEnv("GRPC_PORT")
+	_ = viper.BindEnv("SEARCH_LANGUAGES")
+	_ = viper.BindEnv("COMMENT_EDIT_WINDOW")
+	_ = viper.BindEnv("COMMENT_HELD_WORDS")
+	_ = viper.BindEnv("COMMENT_REPORT_THRESHOLD")
+	_ = viper.BindEnv("ITEM_MAX_IMAGES")
+	_ = viper.BindEnv("IMAGE_UPLOAD_URL_TTL")
+	_ = viper.BindEnv("IMAGE_UPLOAD_MAX_BYTES")
+	_ = viper.BindEnv("IMAGE_RENDITIONS")
+	_ = viper.BindEnv("IMAGE_RENDITION_FORMATS")
+	_ = viper.BindEnv("IMAGE_QUALITY")
 }
 
 func setDefaults() {
@@ -68,4 +94,13 @@ func setDefaults() {
 	viper.SetDefault("POSTGRES_PORT", "5432")
 	viper.SetDefault("SERVICE_NAME", "auction")
 	viper.SetDefault("GRPC_PORT", "9090")
+	viper.SetDefault("SEARCH_LANGUAGES", "english")
+	viper.SetDefault("COMMENT_EDIT_WINDOW", "15m")
+	viper.SetDefault("COMMENT_REPORT_THRESHOLD", 3)
+	viper.SetDefault("ITEM_MAX_IMAGES", 10)
+	viper.SetDefault("IMAGE_UPLOAD_URL_TTL", "15m")
+	viper.SetDefault("IMAGE_UPLOAD_MAX_BYTES", 20*1024*1024)
+	viper.SetDefault("IMAGE_RENDITIONS", "thumb:200x200,medium:800x800,large:1600x1600")
+	viper.SetDefault("IMAGE_RENDITION_FORMATS", "jpeg")
+	viper.SetDefault("IMAGE_QUALITY", 82)
 }
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Domain constants
+const (
+	BidDomain   = "bid"
+	BidExchange = "bid.bidding"
+)
+
+// Event names
+const (
+	BidPlacedEvent    = "bid.placed"
+	BidWonEvent       = "bid.won"
+	BidCancelledEvent = "bid.cancelled"
+)
+
+var (
+	ErrUnknownEvent   = errors.New("unknown event")
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// BidPayload is implemented by every typed bid event payload
+type BidPayload interface {
+	Validate() error
+}
+
+// bidPayloads maps "<event>.<version>" to a constructor for its payload type.
+// A new payload version is supported by registering its routing key here.
+var bidPayloads = map[string]func() BidPayload{
+	BidPlacedEvent + "." + EventVersionV1:    func() BidPayload { return &BidPlacedPayload{} },
+	BidWonEvent + "." + EventVersionV1:       func() BidPayload { return &BidWonPayload{} },
+	BidCancelledEvent + "." + EventVersionV1: func() BidPayload { return &BidCancelledPayload{} },
+}
+
+// DecodeBidPayload decodes and validates the payload of a bid event according
+// to its name and version.
+// JSON keys are matched case-insensitively, so both the documented camelCase
+// keys (itemId) and the legacy PascalCase keys (ItemID) are accepted.
+func DecodeBidPayload(event *Event) (BidPayload, error) {
+	newPayload, ok := bidPayloads[event.GetRoutingKey()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event.GetRoutingKey())
+	}
+
+	payload := newPayload()
+	if err := decodePayload(event, payload); err != nil {
+		return nil, err
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidPayload, event.GetRoutingKey(), err)
+	}
+
+	return payload, nil
+}
+
+// decodePayload unmarshals the payload of an event into v, whether it was
+// received as raw JSON or built in process
+func decodePayload(event *Event, v any) error {
+	var data []byte
+	switch raw := event.Payload.(type) {
+	case json.RawMessage:
+		data = raw
+	case []byte:
+		data = raw
+	default:
+		var err error
+		if data, err = json.Marshal(raw); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidPayload, event.GetRoutingKey(), err)
+	}
+
+	return nil
+}
+
+// BidPlacedPayload represents the payload for bid.placed event
+type BidPlacedPayload struct {
+	BidID        string          `json:"bidId"`
+	ItemID       string          `json:"itemId"`
+	UserID       string          `json:"userId"`
+	Amount       decimal.Decimal `json:"amount"`
+	CurrencyCode string          `json:"currencyCode"`
+	Timestamp    *time.Time      `json:"timestamp"`
+}
+
+func (p *BidPlacedPayload) Validate() error {
+	if p.ItemID == "" {
+		return errors.New("itemId is required")
+	}
+	if p.UserID == "" {
+		return errors.New("userId is required")
+	}
+	if !p.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+// BidWonPayload represents the payload for bid.won event
+type BidWonPayload struct {
+	BidID        string          `json:"bidId"`
+	ItemID       string          `json:"itemId"`
+	BuyerID      string          `json:"buyerId"`
+	FinalAmount  decimal.Decimal `json:"finalAmount"`
+	CurrencyCode string          `json:"currencyCode"`
+}
+
+func (p *BidWonPayload) Validate() error {
+	if p.ItemID == "" {
+		return errors.New("itemId is required")
+	}
+	if p.BuyerID == "" {
+		return errors.New("buyerId is required")
+	}
+	if !p.FinalAmount.IsPositive() {
+		return errors.New("finalAmount must be positive")
+	}
+
+	return nil
+}
+
+// BidCancelledPayload represents the payload for bid.cancelled event
+type BidCancelledPayload struct {
+	BidID       string    `json:"bidId"`
+	ItemID      string    `json:"itemId"`
+	UserID      string    `json:"userId"`
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelledAt"`
+}
+
+func (p *BidCancelledPayload) Validate() error {
+	if p.BidID == "" {
+		return errors.New("bidId is required")
+	}
+	if p.ItemID == "" {
+		return errors.New("itemId is required")
+	}
+
+	return nil
+}
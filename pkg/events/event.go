@@ -8,6 +8,7 @@ import (
 )
 
 type Event struct {
+	ID            string      `json:"id"`            // Unique event ID, stable across redeliveries
 	Event         string      `json:"event"`         // e.g., "item.created"
 	Version       string      `json:"version"`       // e.g., "v1"
 	Timestamp     time.Time   `json:"timestamp"`     // Event occurrence time
@@ -24,6 +25,7 @@ type Headers struct {
 
 func NewEvent(eventName, version string, payload interface{}, headers Headers) *Event {
 	return &Event{
+		ID:            uuid.New().String(),
 		Event:         eventName,
 		Version:       version,
 		Timestamp:     time.Now().UTC(),
@@ -48,3 +50,21 @@ func GenerateTraceID() string {
 func GenerateCorrelationID() string {
 	return uuid.New().String()
 }
+
+// DecodeEvent parses a serialized event, keeping the payload as raw JSON
+// so it can be re-published byte for byte or decoded into a typed struct.
+func DecodeEvent(data []byte) (*Event, error) {
+	var envelope struct {
+		Event
+		Payload json.RawMessage `json:"payload"`
+	}
+
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+
+	event := envelope.Event
+	event.Payload = envelope.Payload
+
+	return &event, nil
+}
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -14,15 +16,35 @@ const (
 
 // Event names
 const (
-	ItemCreatedEvent          = "item.created"
-	ItemUpdatedEvent          = "item.updated"
-	ItemDeletedEvent          = "item.deleted"
-	ItemCommentCreatedEvent   = "item.comment.created"
-	ItemCommentDeletedEvent   = "item.comment.deleted"
-	ItemImageUploadedEvent    = "item.image.uploaded"
-	ItemImageDeletedEvent     = "item.image.deleted"
-	ItemAttributeCreatedEvent = "item.attribute.created"
-	ItemAttributeDeletedEvent = "item.attribute.deleted"
+	ItemCreatedEvent             = "item.created"
+	ItemUpdatedEvent             = "item.updated"
+	ItemDeletedEvent             = "item.deleted"
+	ItemCommentCreatedEvent      = "item.comment.created"
+	ItemCommentUpdatedEvent      = "item.comment.updated"
+	ItemCommentDeletedEvent      = "item.comment.deleted"
+	ItemCommentReportedEvent     = "item.comment.reported"
+	ItemCommentModeratedEvent    = "item.comment.moderated"
+	ItemQuestionAnsweredEvent    = "item.question.answered"
+	ItemImageUploadedEvent       = "item.image.uploaded"
+	ItemImageDeletedEvent        = "item.image.deleted"
+	ItemImageProcessedEvent      = "item.image.processed"
+	ItemImagesReorderedEvent     = "item.images.reordered"
+	ItemPrimaryImageChangedEvent = "item.primary_image.changed"
+	ItemAttributeCreatedEvent    = "item.attribute.created"
+	ItemAttributeDeletedEvent    = "item.attribute.deleted"
+	ItemAttributesChangedEvent   = "item.attributes.changed"
+	ItemCategoriesChangedEvent   = "item.categories.changed"
+	ItemStartedEvent             = "item.started"
+	ItemEndedEvent               = "item.ended"
+	ItemSoldEvent                = "item.sold"
+	ItemUnsoldEvent              = "item.unsold"
+	ItemBidRejectedEvent         = "item.bid.rejected"
+)
+
+// Reasons carried by item.sold
+const (
+	ItemSoldReasonAuction = "auction"
+	ItemSoldReasonBuyout  = "buyout"
 )
 
 // Event versions
@@ -77,9 +99,21 @@ type ItemCommentCreatedPayload struct {
 	ItemID    string    `json:"itemId"`
 	AuthorID  string    `json:"authorId"`
 	Content   string    `json:"content"`
+	Kind      string    `json:"kind"`   // "comment", "question" or "answer"
+	Status    string    `json:"status"` // "visible", or "pending" when held for review
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+type ItemCommentUpdatedPayload struct {
+	ID              string    `json:"id"`
+	ItemID          string    `json:"itemId"`
+	AuthorID        string    `json:"authorId"`
+	Content         string    `json:"content"`
+	PreviousContent string    `json:"previousContent"`
+	Status          string    `json:"status"` // pending when the moderator held the edit
+	EditedAt        time.Time `json:"editedAt"`
+}
+
 type ItemCommentDeletedPayload struct {
 	ID        string    `json:"id"`
 	ItemID    string    `json:"itemId"`
@@ -87,11 +121,90 @@ type ItemCommentDeletedPayload struct {
 	DeletedAt time.Time `json:"deletedAt"`
 }
 
+type ItemCommentReportedPayload struct {
+	ID          string    `json:"id"`
+	ItemID      string    `json:"itemId"`
+	AuthorID    string    `json:"authorId"`
+	ReporterID  string    `json:"reporterId"`
+	Reason      string    `json:"reason"`
+	ReportCount int       `json:"reportCount"`
+	ReportedAt  time.Time `json:"reportedAt"`
+}
+
+type ItemCommentModeratedPayload struct {
+	ID             string    `json:"id"`
+	ItemID         string    `json:"itemId"`
+	AuthorID       string    `json:"authorId"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previousStatus"`
+	ModeratorID    *string   `json:"moderatorId"` // nil when moderated automatically
+	Reason         *string   `json:"reason"`
+	ModeratedAt    time.Time `json:"moderatedAt"`
+}
+
+type ItemQuestionAnsweredPayload struct {
+	QuestionID string    `json:"questionId"`
+	AnswerID   string    `json:"answerId"`
+	ItemID     string    `json:"itemId"`
+	SellerID   string    `json:"sellerId"`
+	AskerID    string    `json:"askerId"`
+	Question   string    `json:"question"`
+	Answer     string    `json:"answer"`
+	AnsweredAt time.Time `json:"answeredAt"`
+}
+
 type ItemImageUploadedPayload struct {
-	ID        string    `json:"id"`
-	ItemID    string    `json:"itemId"`
-	ImageURL  string    `json:"imageUrl"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID           string    `json:"id"`
+	ItemID       string    `json:"itemId"`
+	ImageURL     string    `json:"imageUrl"`
+	DisplayOrder int       `json:"displayOrder"`
+	IsPrimary    bool      `json:"isPrimary"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// DecodeItemImageUploadedPayload decodes and validates the payload of an
+// item.image.uploaded event
+func DecodeItemImageUploadedPayload(event *Event) (*ItemImageUploadedPayload, error) {
+	if event.GetRoutingKey() != ItemImageUploadedEvent+"."+EventVersionV1 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event.GetRoutingKey())
+	}
+
+	payload := &ItemImageUploadedPayload{}
+	if err := decodePayload(event, payload); err != nil {
+		return nil, err
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidPayload, event.GetRoutingKey(), err)
+	}
+
+	return payload, nil
+}
+
+func (p *ItemImageUploadedPayload) Validate() error {
+	if p.ID == "" {
+		return errors.New("id is required")
+	}
+	if p.ItemID == "" {
+		return errors.New("itemId is required")
+	}
+
+	return nil
+}
+
+type ItemImageProcessedPayload struct {
+	ID          string             `json:"id"`
+	ItemID      string             `json:"itemId"`
+	Variants    []ItemImageVariant `json:"variants"`
+	ProcessedAt time.Time          `json:"processedAt"`
+}
+
+type ItemImageVariant struct {
+	Size     string `json:"size"`
+	Format   string `json:"format"`
+	ImageURL string `json:"imageUrl"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
 }
 
 type ItemImageDeletedPayload struct {
@@ -101,6 +214,22 @@ type ItemImageDeletedPayload struct {
 	DeletedAt time.Time `json:"deletedAt"`
 }
 
+type ItemImagesReorderedPayload struct {
+	ItemID      string    `json:"itemId"`
+	SellerID    string    `json:"sellerId"`
+	ImageIDs    []string  `json:"imageIds"` // Every image of the item in display order
+	ReorderedAt time.Time `json:"reorderedAt"`
+}
+
+type ItemPrimaryImageChangedPayload struct {
+	ItemID          string    `json:"itemId"`
+	SellerID        string    `json:"sellerId"`
+	ImageID         string    `json:"imageId"`
+	ImageURL        string    `json:"imageUrl"`
+	PreviousImageID *string   `json:"previousImageId"`
+	ChangedAt       time.Time `json:"changedAt"`
+}
+
 type ItemAttributeDeletedPayload struct {
 	ID        string    `json:"id"`
 	ItemID    string    `json:"itemId"`
@@ -114,3 +243,86 @@ type ItemAttributeCreatedPayload struct {
 	Value     string    `json:"value"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+type ItemAttributeValue struct {
+	ID    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ItemAttributeValueChange struct {
+	ID            string `json:"id"`
+	Key           string `json:"key"`
+	Value         string `json:"value"`
+	PreviousKey   string `json:"previousKey"`
+	PreviousValue string `json:"previousValue"`
+}
+
+type ItemAttributesChangedPayload struct {
+	ItemID    string                     `json:"itemId"`
+	SellerID  string                     `json:"sellerId"`
+	Added     []ItemAttributeValue       `json:"added"`
+	Updated   []ItemAttributeValueChange `json:"updated"`
+	Removed   []ItemAttributeValue       `json:"removed"`
+	ChangedAt time.Time                  `json:"changedAt"`
+}
+
+type ItemCategoriesChangedPayload struct {
+	ItemID      string    `json:"itemId"`
+	SellerID    string    `json:"sellerId"`
+	CategoryIDs []string  `json:"categoryIds"` // Categories assigned after the change
+	Added       []string  `json:"added"`
+	Removed     []string  `json:"removed"`
+	ChangedAt   time.Time `json:"changedAt"`
+}
+
+type ItemStartedPayload struct {
+	ID           string          `json:"id"`
+	SellerID     string          `json:"sellerId"`
+	CurrencyCode string          `json:"currencyCode"`
+	StartPrice   decimal.Decimal `json:"startPrice"`
+	StartDate    time.Time       `json:"startDate"`
+	EndDate      time.Time       `json:"endDate"`
+	StartedAt    time.Time       `json:"startedAt"`
+}
+
+type ItemEndedPayload struct {
+	ID           string           `json:"id"`
+	SellerID     string           `json:"sellerId"`
+	Status       string           `json:"status"`
+	CurrencyCode string           `json:"currencyCode"`
+	FinalPrice   *decimal.Decimal `json:"finalPrice"`
+	BidCount     int              `json:"bidCount"`
+	EndedAt      time.Time        `json:"endedAt"`
+}
+
+type ItemSoldPayload struct {
+	ID           string          `json:"id"`
+	SellerID     string          `json:"sellerId"`
+	BuyerID      *string         `json:"buyerId"`
+	CurrencyCode string          `json:"currencyCode"`
+	FinalPrice   decimal.Decimal `json:"finalPrice"`
+	Reason       string          `json:"reason"` // "auction" or "buyout"
+	SoldAt       time.Time       `json:"soldAt"`
+}
+
+type ItemUnsoldPayload struct {
+	ID           string          `json:"id"`
+	SellerID     string          `json:"sellerId"`
+	Reason       string          `json:"reason"` // "no_bids" or "reserve_not_met"
+	CurrencyCode string          `json:"currencyCode"`
+	HighestBid   decimal.Decimal `json:"highestBid"`
+	EndedAt      time.Time       `json:"endedAt"`
+}
+
+type ItemBidRejectedPayload struct {
+	BidID        string          `json:"bidId"`
+	ItemID       string          `json:"itemId"`
+	UserID       string          `json:"userId"`
+	Amount       decimal.Decimal `json:"amount"`
+	CurrencyCode string          `json:"currencyCode"`
+	Reason       string          `json:"reason"`
+	CurrentPrice decimal.Decimal `json:"currentPrice"`
+	MinimumBid   decimal.Decimal `json:"minimumBid"`
+	RejectedAt   time.Time       `json:"rejectedAt"`
+}
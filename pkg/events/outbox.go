@@ -0,0 +1,34 @@
+package events
+
+import "time"
+
+// OutboxMessage is an event stored in the outbox table, waiting to be relayed
+type OutboxMessage struct {
+	ID            string     `db:"id"`
+	Exchange      string     `db:"exchange"`
+	RoutingKey    string     `db:"routing_key"`
+	EventName     string     `db:"event_name"`
+	Payload       []byte     `db:"payload"`
+	TraceID       *string    `db:"trace_id"`
+	CorrelationID *string    `db:"correlation_id"`
+	Attempts      int        `db:"attempts"`
+	LastError     *string    `db:"last_error"`
+	PublishedAt   *time.Time `db:"published_at"`
+	FailedAt      *time.Time `db:"failed_at"`    // Set when the message is parked
+	LockedUntil   *time.Time `db:"locked_until"` // End of the lease of the relay publishing it
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// Headers returns the message headers stored alongside the event
+func (m OutboxMessage) Headers() Headers {
+	headers := Headers{Service: "auction"}
+
+	if m.TraceID != nil {
+		headers.TraceID = *m.TraceID
+	}
+	if m.CorrelationID != nil {
+		headers.CorrelationID = *m.CorrelationID
+	}
+
+	return headers
+}
@@ -2,8 +2,13 @@ package events
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrBrokerUnavailable is returned by Publish when the broker cannot be
+// reached, as opposed to failures caused by the event itself
+var ErrBrokerUnavailable = errors.New("broker unavailable")
+
 // Publisher defines the interface for publishing domain events
 type Publisher interface {
 	// Publish publishes an event to the message broker
@@ -10,12 +10,25 @@ type Error struct {
 	Code    string      `json:"code"`
 	Message string      `json:"message"`
 	Details interface{} `json:"details,omitempty"`
+	Err     error       `json:"-"` // Cause, for callers other than the HTTP API
 }
 
 func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCause sets the error the response was made from, so callers can match
+// it with errors.Is and errors.As
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+
+	return e
+}
+
 func New(status int, code, message string, details interface{}) *Error {
 	if status == 0 {
 		status = http.StatusInternalServerError
@@ -72,3 +85,7 @@ func Accepted(code, message string, details interface{}) *Error {
 func Forbidden(code, message string, details interface{}) *Error {
 	return New(http.StatusForbidden, code, message, details)
 }
+
+func Gone(code, message string, details interface{}) *Error {
+	return New(http.StatusGone, code, message, details)
+}
@@ -0,0 +1,246 @@
+// Package imageproc renders scaled down copies of uploaded images and strips
+// their metadata. It only uses pure Go decoders and encoders.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+// maxPixels bounds the size of the images that are decoded, so a small file
+// declaring huge dimensions cannot exhaust the memory of the worker
+const maxPixels = 64 << 20
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image too large")
+)
+
+type Format string
+
+// Formats images are read and renditions are encoded in
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+	FormatWebP Format = "webp"
+)
+
+func (f Format) ContentType() string {
+	return "image/" + string(f)
+}
+
+func (f Format) Extension() string {
+	if f == FormatJPEG {
+		return ".jpg"
+	}
+
+	return "." + string(f)
+}
+
+// Rendition is a named box images are scaled down to fit in. Images that
+// already fit are not scaled up.
+type Rendition struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+var renditionPattern = regexp.MustCompile(`^([a-z0-9_-]+):(\d+)x(\d+)$`)
+
+// ParseRenditions parses a comma separated list of renditions, e.g.
+// "thumb:200x200,medium:800x800"
+func ParseRenditions(setting string) ([]Rendition, error) {
+	renditions := make([]Rendition, 0)
+	for _, entry := range strings.Split(setting, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		match := renditionPattern.FindStringSubmatch(entry)
+		if match == nil {
+			return nil, fmt.Errorf("invalid rendition %q, expected name:WIDTHxHEIGHT", entry)
+		}
+
+		width, _ := strconv.Atoi(match[2])
+		height, _ := strconv.Atoi(match[3])
+		if width < 1 || height < 1 || width > 16383 || height > 16383 {
+			return nil, fmt.Errorf("invalid rendition %q, sizes range from 1 to 16383", entry)
+		}
+
+		if slices.ContainsFunc(renditions, func(r Rendition) bool { return r.Name == match[1] }) {
+			return nil, fmt.Errorf("duplicate rendition %q", match[1])
+		}
+
+		renditions = append(renditions, Rendition{Name: match[1], Width: width, Height: height})
+	}
+
+	return renditions, nil
+}
+
+// ParseFormats parses a comma separated list of rendition formats, e.g.
+// "jpeg,png". WebP is decoded but not encoded, so it is not a rendition format.
+func ParseFormats(setting string) ([]Format, error) {
+	formats := make([]Format, 0)
+	for _, entry := range strings.Split(setting, ",") {
+		format := Format(strings.ToLower(strings.TrimSpace(entry)))
+		switch format {
+		case "":
+			continue
+		case FormatJPEG, FormatPNG:
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, entry)
+		}
+
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+
+	return formats, nil
+}
+
+// Variant is a rendition of an image encoded in one format
+type Variant struct {
+	Rendition string
+	Format    Format
+	Width     int
+	Height    int
+	Data      []byte
+}
+
+// Result is a processed image
+type Result struct {
+	Format Format // Format of the original
+	// Original is the original without its metadata, nil when it had none
+	Original []byte
+	Variants []Variant
+}
+
+// Processor renders every rendition of an image in every format. Quality,
+// from 1 to 100, applies to the lossy JPEG encoding.
+type Processor struct {
+	renditions []Rendition
+	formats    []Format
+	quality    int
+}
+
+func NewProcessor(renditions []Rendition, formats []Format, quality int) *Processor {
+	if quality < 1 || quality > 100 {
+		quality = jpeg.DefaultQuality
+	}
+
+	return &Processor{
+		renditions: renditions,
+		formats:    formats,
+		quality:    quality,
+	}
+}
+
+// Renditions returns the renditions rendered of every image
+func (p *Processor) Renditions() []Rendition {
+	return p.renditions
+}
+
+// Formats returns the formats every rendition is encoded in
+func (p *Processor) Formats() []Format {
+	return p.formats
+}
+
+// Process decodes a JPEG, PNG or WebP image, applying its EXIF orientation,
+// strips its metadata and renders its variants
+func (p *Processor) Process(data []byte) (Result, error) {
+	config, name, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, err)
+	}
+
+	format := Format(name)
+	if format != FormatJPEG && format != FormatPNG && format != FormatWebP {
+		return Result{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+	if config.Width*config.Height > maxPixels {
+		return Result{}, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	original, err := p.stripMetadata(data, format, img)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		Format:   format,
+		Original: original,
+		Variants: make([]Variant, 0, len(p.renditions)*len(p.formats)),
+	}
+
+	for _, rendition := range p.renditions {
+		scaled := imaging.Fit(img, rendition.Width, rendition.Height, imaging.Lanczos)
+		bounds := scaled.Bounds()
+
+		for _, format := range p.formats {
+			encoded, err := p.encode(scaled, format)
+			if err != nil {
+				return Result{}, fmt.Errorf("failed to encode %s rendition as %s: %w", rendition.Name, format, err)
+			}
+
+			result.Variants = append(result.Variants, Variant{
+				Rendition: rendition.Name,
+				Format:    format,
+				Width:     bounds.Dx(),
+				Height:    bounds.Dy(),
+				Data:      encoded,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Processor) encode(img image.Image, format Format) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: p.quality})
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// flatten puts transparent images on a white background, JPEG has no alpha
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+
+	bounds := img.Bounds()
+	background := imaging.New(bounds.Dx(), bounds.Dy(), color.White)
+
+	return imaging.Overlay(background, img, image.Point{}, 1)
+}
@@ -0,0 +1,504 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func testImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 6), G: uint8(y * 6), B: 128, A: 255})
+		}
+	}
+
+	return img
+}
+
+// exifSegment returns an APP1 segment with a little endian TIFF structure
+// holding only the orientation tag
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("II*\x00")
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // Value padding and next IFD
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xff, jpegAPP1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+func commentSegment(text string) []byte {
+	segment := []byte{0xff, jpegCOM}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(text)+2))
+
+	return append(segment, text...)
+}
+
+// jpegWithSegments encodes a w×h JPEG and inserts segments after its SOI marker
+func jpegWithSegments(t *testing.T, w, h int, segments ...[]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(w, h), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data := append([]byte{}, buf.Bytes()[:2]...)
+	for _, segment := range segments {
+		data = append(data, segment...)
+	}
+
+	return append(data, buf.Bytes()[2:]...)
+}
+
+func pngChunk(kind string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// pngWithChunks encodes a w×h PNG and inserts chunks after its IHDR chunk
+func pngWithChunks(t *testing.T, w, h int, chunks ...[]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(w, h)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Signature and the 25 bytes of IHDR
+	ihdrEnd := len(pngSignature) + 25
+	data := append([]byte{}, buf.Bytes()[:ihdrEnd]...)
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+
+	return append(data, buf.Bytes()[ihdrEnd:]...)
+}
+
+// webpWithEXIF wraps the w×h lossless WebP of testdata in the extended format
+// with an EXIF chunk
+func webpWithEXIF(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	simple, err := os.ReadFile(fmt.Sprintf("testdata/%dx%d.webp", w, h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vp8l := simple[12:]
+
+	header := make([]byte, 10)
+	header[0] = 0x08 // EXIF flag
+	header[4], header[5], header[6] = byte(w-1), byte((w-1)>>8), byte((w-1)>>16)
+	header[7], header[8], header[9] = byte(h-1), byte((h-1)>>8), byte((h-1)>>16)
+
+	exif := exifSegment(1)[4:]
+
+	body := []byte("WEBP")
+	body = append(body, "VP8X"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(header)))
+	body = append(body, header...)
+	body = append(body, vp8l...)
+	body = append(body, "EXIF"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(exif)))
+	body = append(body, exif...)
+	if len(exif)&1 == 1 {
+		body = append(body, 0)
+	}
+
+	data := []byte("RIFF")
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(body)))
+
+	return append(data, body...)
+}
+
+func hasJPEGMarker(t *testing.T, data []byte, marker byte) bool {
+	t.Helper()
+
+	segments, _, err := jpegSegments(data)
+	if err != nil {
+		t.Fatalf("jpegSegments: %v", err)
+	}
+
+	for _, segment := range segments {
+		if segment.marker == marker {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newTestProcessor() *Processor {
+	return NewProcessor(
+		[]Rendition{{Name: "thumb", Width: 10, Height: 10}, {Name: "large", Width: 100, Height: 100}},
+		[]Format{FormatJPEG, FormatPNG},
+		80,
+	)
+}
+
+func TestProcessAppliesJPEGOrientation(t *testing.T) {
+	// Orientation 6 turns the stored 40x20 pixels into an upright 20x40 image
+	data := jpegWithSegments(t, 40, 20, exifSegment(6))
+
+	result, err := newTestProcessor().Process(data)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if result.Format != FormatJPEG {
+		t.Errorf("Format = %s, want jpeg", result.Format)
+	}
+	if result.Original == nil {
+		t.Fatal("Original is nil, want the image without its EXIF")
+	}
+	if hasJPEGMarker(t, result.Original, jpegAPP1) {
+		t.Error("Original still has an APP1 segment")
+	}
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(result.Original))
+	if err != nil {
+		t.Fatalf("DecodeConfig: %v", err)
+	}
+	if config.Width != 20 || config.Height != 40 {
+		t.Errorf("Original is %dx%d, want the upright 20x40", config.Width, config.Height)
+	}
+
+	for _, variant := range result.Variants {
+		// Renditions fit their box without being scaled up
+		want := image.Pt(20, 40)
+		if variant.Rendition == "thumb" {
+			want = image.Pt(5, 10)
+		}
+		if got := image.Pt(variant.Width, variant.Height); got != want {
+			t.Errorf("%s %s is %v, want %v", variant.Rendition, variant.Format, got, want)
+		}
+
+		decoded, format, err := image.Decode(bytes.NewReader(variant.Data))
+		if err != nil {
+			t.Fatalf("%s %s does not decode: %v", variant.Rendition, variant.Format, err)
+		}
+		if Format(format) != variant.Format || decoded.Bounds().Size() != want {
+			t.Errorf("%s %s decodes as a %v %s", variant.Rendition, variant.Format, decoded.Bounds().Size(), format)
+		}
+	}
+	if got, want := len(result.Variants), 4; got != want {
+		t.Errorf("got %d variants, want %d", got, want)
+	}
+}
+
+func TestProcessStripsUprightJPEGWithoutReencoding(t *testing.T) {
+	data := jpegWithSegments(t, 16, 8, exifSegment(1), commentSegment("taken at home"))
+
+	result, err := newTestProcessor().Process(data)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if hasJPEGMarker(t, result.Original, jpegAPP1) || hasJPEGMarker(t, result.Original, jpegCOM) {
+		t.Error("Original still has its EXIF or comment")
+	}
+
+	_, scans, _ := jpegSegments(data)
+	if !bytes.HasSuffix(result.Original, scans) {
+		t.Error("the scans of the original were re-encoded")
+	}
+}
+
+func TestProcessKeepsJPEGWithoutMetadata(t *testing.T) {
+	data := jpegWithSegments(t, 16, 8)
+
+	result, err := newTestProcessor().Process(data)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if result.Original != nil {
+		t.Error("Original is set for an image without metadata")
+	}
+}
+
+func TestProcessStripsPNG(t *testing.T) {
+	data := pngWithChunks(t, 12, 6,
+		pngChunk("tEXt", []byte("Author\x00someone")),
+		pngChunk("tIME", make([]byte, 7)),
+	)
+
+	result, err := newTestProcessor().Process(data)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	plain := pngWithChunks(t, 12, 6)
+	if !bytes.Equal(result.Original, plain) {
+		t.Error("Original is not the PNG without its text and time chunks")
+	}
+
+	result, err = newTestProcessor().Process(plain)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if result.Original != nil {
+		t.Error("Original is set for a PNG without metadata")
+	}
+}
+
+func TestProcessStripsWebP(t *testing.T) {
+	data := webpWithEXIF(t, 9, 5)
+
+	result, err := newTestProcessor().Process(data)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	original := result.Original
+	if original == nil {
+		t.Fatal("Original is nil, want the image without its EXIF")
+	}
+	if bytes.Contains(original, []byte("EXIF")) {
+		t.Error("Original still has its EXIF chunk")
+	}
+	if flags := original[20]; flags&0x08 != 0 {
+		t.Errorf("VP8X flags = %#x, the EXIF flag is still set", flags)
+	}
+	if size := binary.LittleEndian.Uint32(original[4:]); int(size) != len(original)-8 {
+		t.Errorf("RIFF size = %d, want %d", size, len(original)-8)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil || format != "webp" || config.Width != 9 || config.Height != 5 {
+		t.Errorf("Original decodes as a %dx%d %s: %v", config.Width, config.Height, format, err)
+	}
+}
+
+func TestProcessRejectsUnsupportedImages(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, testImage(4, 4), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A valid header declaring more pixels than maxPixels
+	ihdr := binary.BigEndian.AppendUint32(nil, 1<<14)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, 1<<14)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0)
+	huge := append(append([]byte{}, pngSignature...), pngChunk("IHDR", ihdr)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"garbage", []byte("not an image"), ErrUnsupportedFormat},
+		{"empty", nil, ErrUnsupportedFormat},
+		{"gif", gifData.Bytes(), ErrUnsupportedFormat},
+		{"too large", huge, ErrImageTooLarge},
+	}
+
+	for _, tt := range tests {
+		if _, err := newTestProcessor().Process(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestStripRejectsMalformedImages(t *testing.T) {
+	jpegData := jpegWithSegments(t, 8, 8, exifSegment(1))
+	pngData := pngWithChunks(t, 8, 8, pngChunk("tEXt", []byte("a\x00b")))
+	webpData := webpWithEXIF(t, 8, 8)
+
+	// A segment length running past the end of the file
+	badSegment := append([]byte{}, jpegData...)
+	binary.BigEndian.PutUint16(badSegment[4:], 0xfff0)
+
+	// A chunk length running past the end of the file
+	badChunk := append([]byte{}, pngData...)
+	binary.BigEndian.PutUint32(badChunk[len(pngSignature)+25:], 1<<31)
+
+	tests := []struct {
+		name  string
+		strip func([]byte) ([]byte, error)
+		data  []byte
+	}{
+		{"jpeg without SOI", stripJPEG, jpegData[2:]},
+		{"jpeg truncated before the scans", stripJPEG, jpegData[:30]},
+		{"jpeg segment too long", stripJPEG, badSegment},
+		{"png without signature", stripPNG, pngData[1:]},
+		{"png truncated chunk", stripPNG, pngData[:len(pngData)-3]},
+		{"png chunk too long", stripPNG, badChunk},
+		{"webp without header", stripWebP, webpData[4:]},
+		{"webp truncated chunk", stripWebP, webpData[:len(webpData)-5]},
+	}
+
+	for _, tt := range tests {
+		if _, err := tt.strip(tt.data); !errors.Is(err, errMalformedImage) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, errMalformedImage)
+		}
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	bigEndian := exifSegment(8)
+	tiff := bigEndian[10:]
+	tiff[0], tiff[1] = 'M', 'M'
+	binary.BigEndian.PutUint32(tiff[4:], 8)
+	binary.BigEndian.PutUint16(tiff[8:], 1)
+	binary.BigEndian.PutUint16(tiff[10:], exifOrientationTag)
+	binary.BigEndian.PutUint16(tiff[12:], 3)
+	binary.BigEndian.PutUint32(tiff[14:], 1)
+	binary.BigEndian.PutUint16(tiff[18:], 8)
+
+	// An IFD offset pointing past the TIFF structure
+	badOffset := exifSegment(6)
+	binary.LittleEndian.PutUint32(badOffset[14:], 0xffff)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"little endian", jpegWithSegments(t, 8, 8, exifSegment(3)), 3},
+		{"big endian", jpegWithSegments(t, 8, 8, bigEndian), 8},
+		{"no EXIF", jpegWithSegments(t, 8, 8), 1},
+		{"IFD out of range", jpegWithSegments(t, 8, 8, badOffset), 1},
+		{"not a JPEG", []byte("nope"), 1},
+	}
+
+	for _, tt := range tests {
+		if got := jpegOrientation(tt.data); got != tt.want {
+			t.Errorf("%s: orientation = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseRenditions(t *testing.T) {
+	renditions, err := ParseRenditions(" Thumb:200x150, medium:800x800,,")
+	if err != nil {
+		t.Fatalf("ParseRenditions: %v", err)
+	}
+
+	want := []Rendition{{"thumb", 200, 150}, {"medium", 800, 800}}
+	if !reflect.DeepEqual(renditions, want) {
+		t.Errorf("renditions = %v, want %v", renditions, want)
+	}
+
+	for _, setting := range []string{"thumb", "thumb:0x10", "thumb:10x16384", "a:1x1,a:2x2", "bad name:1x1"} {
+		if _, err := ParseRenditions(setting); err == nil {
+			t.Errorf("ParseRenditions(%q) succeeded", setting)
+		}
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats("PNG, jpeg,png,")
+	if err != nil {
+		t.Fatalf("ParseFormats: %v", err)
+	}
+
+	if want := []Format{FormatPNG, FormatJPEG}; !reflect.DeepEqual(formats, want) {
+		t.Errorf("formats = %v, want %v", formats, want)
+	}
+
+	for _, setting := range []string{"jpeg,gif", "jpeg,webp"} {
+		if _, err := ParseFormats(setting); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("ParseFormats(%q): err = %v, want %v", setting, err, ErrUnsupportedFormat)
+		}
+	}
+}
+
+func TestStripKeepsJPEGOrientation(t *testing.T) {
+	data := jpegWithSegments(t, 16, 8, exifSegment(6), commentSegment("taken at home"))
+
+	stripped, format, err := Strip(data)
+	if err != nil {
+		t.Fatalf("Strip: %v", err)
+	}
+
+	if format != FormatJPEG {
+		t.Errorf("format = %s, want jpeg", format)
+	}
+	if hasJPEGMarker(t, stripped, jpegCOM) {
+		t.Error("the comment was kept")
+	}
+	if got := jpegOrientation(stripped); got != 6 {
+		t.Errorf("orientation = %d, want 6", got)
+	}
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+	if err != nil || config.Width != 16 || config.Height != 8 {
+		t.Errorf("stripped image decodes as %dx%d: %v", config.Width, config.Height, err)
+	}
+
+	// Stripping again finds nothing left to remove
+	again, _, err := Strip(stripped)
+	if err != nil || again != nil {
+		t.Errorf("Strip of a stripped image = %d bytes, %v; want nil", len(again), err)
+	}
+}
+
+func TestStripDoesNotDecode(t *testing.T) {
+	// IHDR declares more pixels than Process accepts and no image data follows
+	ihdr := binary.BigEndian.AppendUint32(nil, 1<<14)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, 1<<14)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0)
+	header := append(append([]byte{}, pngSignature...), pngChunk("IHDR", ihdr)...)
+	data := append(append([]byte{}, header...), pngChunk("eXIf", exifSegment(1)[10:])...)
+
+	stripped, format, err := Strip(data)
+	if err != nil {
+		t.Fatalf("Strip: %v", err)
+	}
+
+	if format != FormatPNG || !bytes.Equal(stripped, header) {
+		t.Errorf("Strip = %s %x, want png %x", format, stripped, header)
+	}
+}
+
+func TestStripStripsWebP(t *testing.T) {
+	stripped, format, err := Strip(webpWithEXIF(t, 9, 5))
+	if err != nil {
+		t.Fatalf("Strip: %v", err)
+	}
+
+	if format != FormatWebP || stripped == nil || bytes.Contains(stripped, []byte("EXIF")) {
+		t.Errorf("Strip = %s, %d bytes, want webp without EXIF", format, len(stripped))
+	}
+}
+
+func TestStripRejectsOtherFormats(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, testImage(4, 4), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range [][]byte{nil, []byte("RIFF"), gifData.Bytes()} {
+		if _, _, err := Strip(data); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("Strip(%.8q): err = %v, want %v", data, err, ErrUnsupportedFormat)
+		}
+	}
+
+	// Recognized but malformed
+	if _, format, err := Strip([]byte{0xff, 0xd8, 0xff, 0xe1, 0xff}); format != FormatJPEG || !errors.Is(err, errMalformedImage) {
+		t.Errorf("Strip of a truncated JPEG = %s, %v; want jpeg, %v", format, err, errMalformedImage)
+	}
+}
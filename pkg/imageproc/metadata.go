@@ -0,0 +1,322 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"slices"
+)
+
+var errMalformedImage = errors.New("malformed image")
+
+const (
+	jpegSOS  = 0xda // Start of scan, entropy coded data follows
+	jpegAPP0 = 0xe0 // JFIF
+	jpegAPP1 = 0xe1 // EXIF and XMP
+	jpegAPP2 = 0xe2 // ICC profile
+	jpegAPPE = 0xee // Adobe color transform
+	jpegAPPF = 0xef
+	jpegCOM  = 0xfe
+
+	exifOrientationTag = 0x0112
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the PNG chunks that hold text, EXIF or timestamps
+var pngMetadataChunks = []string{"tEXt", "zTXt", "iTXt", "eXIf", "tIME"}
+
+// stripMetadata returns data without EXIF, XMP, comments and text chunks, or
+// nil when it had none. Color profiles are kept. JPEGs that EXIF rotates are
+// re-encoded from upright, since the rotation is lost with the EXIF.
+func (p *Processor) stripMetadata(data []byte, format Format, upright image.Image) ([]byte, error) {
+	switch format {
+	case FormatJPEG:
+		if jpegOrientation(data) > 1 {
+			return p.encode(upright, FormatJPEG)
+		}
+		return stripJPEG(data)
+	case FormatPNG:
+		return stripPNG(data)
+	case FormatWebP:
+		return stripWebP(data)
+	default:
+		return nil, nil
+	}
+}
+
+// Strip removes the metadata of a JPEG, PNG or WebP image without decoding
+// it, for images Process rejects, and returns nil when there was none. JPEGs
+// keep their EXIF orientation in a minimal EXIF segment, as their pixels are
+// not turned upright.
+func Strip(data []byte) ([]byte, Format, error) {
+	var format Format
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff}):
+		format = FormatJPEG
+	case bytes.HasPrefix(data, pngSignature):
+		format = FormatPNG
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		format = FormatWebP
+	default:
+		return nil, "", ErrUnsupportedFormat
+	}
+
+	var stripped []byte
+	var err error
+	switch format {
+	case FormatJPEG:
+		stripped, err = stripJPEG(data)
+		if orientation := jpegOrientation(data); stripped != nil && orientation > 1 {
+			stripped = slices.Concat(stripped[:2], exifOrientationSegment(orientation), stripped[2:])
+			// Stripped before, only the orientation was left
+			if bytes.Equal(stripped, data) {
+				stripped = nil
+			}
+		}
+	case FormatPNG:
+		stripped, err = stripPNG(data)
+	case FormatWebP:
+		stripped, err = stripWebP(data)
+	}
+	if err != nil {
+		return nil, format, err
+	}
+
+	return stripped, format, nil
+}
+
+// exifOrientationSegment returns an APP1 segment whose EXIF only holds
+// orientation
+func exifOrientationSegment(orientation int) []byte {
+	segment := []byte{0xff, jpegAPP1, 0, 34}
+	segment = append(segment, "Exif\x00\x00MM\x00\x2a"...)
+	segment = binary.BigEndian.AppendUint32(segment, 8) // First IFD
+	segment = binary.BigEndian.AppendUint16(segment, 1) // Entries
+	segment = binary.BigEndian.AppendUint16(segment, exifOrientationTag)
+	segment = binary.BigEndian.AppendUint16(segment, 3) // SHORT
+	segment = binary.BigEndian.AppendUint32(segment, 1) // Count
+	segment = binary.BigEndian.AppendUint16(segment, uint16(orientation))
+	segment = append(segment, 0, 0) // Value padding
+
+	return binary.BigEndian.AppendUint32(segment, 0) // No next IFD
+}
+
+// jpegSegment is a marker segment of a JPEG before the first scan
+type jpegSegment struct {
+	marker byte
+	data   []byte // The whole segment, marker included
+}
+
+// jpegSegments splits data into its segments up to the first scan and the
+// rest of the file, which is kept as is
+func jpegSegments(data []byte) ([]jpegSegment, []byte, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, nil, errMalformedImage
+	}
+
+	var segments []jpegSegment
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return nil, nil, errMalformedImage
+		}
+		// Markers may be preceded by fill bytes
+		if data[i+1] == 0xff {
+			i++
+			continue
+		}
+
+		marker := data[i+1]
+		if marker == jpegSOS {
+			return segments, data[i:], nil
+		}
+
+		// The length counts itself but not the marker
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, nil, errMalformedImage
+		}
+
+		segments = append(segments, jpegSegment{marker: marker, data: data[i:end]})
+		i = end
+	}
+
+	return nil, nil, errMalformedImage
+}
+
+// isJPEGMetadata reports whether a segment holds metadata rather than what
+// decoders need. JFIF, ICC profiles and the Adobe segment are kept.
+func isJPEGMetadata(marker byte) bool {
+	switch marker {
+	case jpegAPP0, jpegAPP2, jpegAPPE:
+		return false
+	case jpegCOM:
+		return true
+	default:
+		return marker >= jpegAPP1 && marker <= jpegAPPF
+	}
+}
+
+func stripJPEG(data []byte) ([]byte, error) {
+	segments, scans, err := jpegSegments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(segments, func(s jpegSegment) bool { return isJPEGMetadata(s.marker) }) {
+		return nil, nil
+	}
+
+	stripped := make([]byte, 0, len(data))
+	stripped = append(stripped, 0xff, 0xd8)
+	for _, segment := range segments {
+		if !isJPEGMetadata(segment.marker) {
+			stripped = append(stripped, segment.data...)
+		}
+	}
+
+	return append(stripped, scans...), nil
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG, 1 (upright) when it
+// has none
+func jpegOrientation(data []byte) int {
+	segments, _, err := jpegSegments(data)
+	if err != nil {
+		return 1
+	}
+
+	for _, segment := range segments {
+		// Marker, length and the "Exif\0\0" header precede the TIFF structure
+		if segment.marker != jpegAPP1 || !bytes.HasPrefix(segment.data[4:], []byte("Exif\x00\x00")) {
+			continue
+		}
+
+		if orientation := tiffOrientation(segment.data[10:]); orientation > 0 {
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the orientation tag of the first IFD of a TIFF
+// structure, 0 when it is missing
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := range entries {
+		entry := ifd + 2 + 12*i
+		if entry+12 > len(tiff) {
+			return 0
+		}
+
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 0
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformedImage
+	}
+
+	stripped := make([]byte, 0, len(data))
+	stripped = append(stripped, pngSignature...)
+
+	changed := false
+	for i := len(pngSignature); i < len(data); {
+		if i+12 > len(data) {
+			return nil, errMalformedImage
+		}
+
+		// Length, type, data and CRC
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i {
+			return nil, errMalformedImage
+		}
+
+		if slices.Contains(pngMetadataChunks, string(data[i+4:i+8])) {
+			changed = true
+		} else {
+			stripped = append(stripped, data[i:end]...)
+		}
+		i = end
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return stripped, nil
+}
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformedImage
+	}
+
+	stripped := make([]byte, 12, len(data))
+	copy(stripped, data[:12])
+
+	changed := false
+	vp8x := -1
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformedImage
+		}
+
+		// Chunks are padded to an even size
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size&1
+		if end > len(data) || end < i {
+			return nil, errMalformedImage
+		}
+
+		switch string(data[i : i+4]) {
+		case "EXIF", "XMP ":
+			changed = true
+		case "VP8X":
+			vp8x = len(stripped)
+			fallthrough
+		default:
+			stripped = append(stripped, data[i:end]...)
+		}
+		i = end
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	if vp8x >= 0 && vp8x+9 <= len(stripped) {
+		// Clear the EXIF and XMP flags of the extended header
+		stripped[vp8x+8] &^= 0x08 | 0x04
+	}
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
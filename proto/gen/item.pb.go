@@ -67,20 +67,25 @@ func (x *GetItemForBidRequest) GetItemId() string {
 }
 
 type GetItemForBidResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	StartPrice    string                 `protobuf:"bytes,6,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
-	CurrentPrice  string                 `protobuf:"bytes,7,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
-	BidIncrement  string                 `protobuf:"bytes,8,opt,name=bid_increment,json=bidIncrement,proto3" json:"bid_increment,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SellerId     string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Status       string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	StartDate    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	StartPrice   string                 `protobuf:"bytes,6,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	CurrentPrice string                 `protobuf:"bytes,7,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	BidIncrement string                 `protobuf:"bytes,8,opt,name=bid_increment,json=bidIncrement,proto3" json:"bid_increment,omitempty"`
+	// Always empty, the reserve amount is not exposed; use reserve_met instead
+	//
+	// Deprecated: Marked as deprecated in item.proto.
 	ReservePrice  string                 `protobuf:"bytes,9,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
 	BuyoutPrice   string                 `protobuf:"bytes,10,opt,name=buyout_price,json=buyoutPrice,proto3" json:"buyout_price,omitempty"`
 	EndPrice      string                 `protobuf:"bytes,11,opt,name=end_price,json=endPrice,proto3" json:"end_price,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	HasReserve    bool                   `protobuf:"varint,14,opt,name=has_reserve,json=hasReserve,proto3" json:"has_reserve,omitempty"`
+	ReserveMet    bool                   `protobuf:"varint,15,opt,name=reserve_met,json=reserveMet,proto3" json:"reserve_met,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -171,6 +176,7 @@ func (x *GetItemForBidResponse) GetBidIncrement() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in item.proto.
 func (x *GetItemForBidResponse) GetReservePrice() string {
 	if x != nil {
 		return x.ReservePrice
@@ -206,6 +212,164 @@ func (x *GetItemForBidResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetItemForBidResponse) GetHasReserve() bool {
+	if x != nil {
+		return x.HasReserve
+	}
+	return false
+}
+
+func (x *GetItemForBidResponse) GetReserveMet() bool {
+	if x != nil {
+		return x.ReserveMet
+	}
+	return false
+}
+
+type BuyoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,2,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyoutRequest) Reset() {
+	*x = BuyoutRequest{}
+	mi := &file_item_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyoutRequest) ProtoMessage() {}
+
+func (x *BuyoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_item_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyoutRequest.ProtoReflect.Descriptor instead.
+func (*BuyoutRequest) Descriptor() ([]byte, []int) {
+	return file_item_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuyoutRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *BuyoutRequest) GetBuyerId() string {
+	if x != nil {
+		return x.BuyerId
+	}
+	return ""
+}
+
+type BuyoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,3,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CurrencyCode  string                 `protobuf:"bytes,5,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	FinalPrice    string                 `protobuf:"bytes,6,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	SoldAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=sold_at,json=soldAt,proto3" json:"sold_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyoutResponse) Reset() {
+	*x = BuyoutResponse{}
+	mi := &file_item_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyoutResponse) ProtoMessage() {}
+
+func (x *BuyoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_item_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyoutResponse.ProtoReflect.Descriptor instead.
+func (*BuyoutResponse) Descriptor() ([]byte, []int) {
+	return file_item_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BuyoutResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetBuyerId() string {
+	if x != nil {
+		return x.BuyerId
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetFinalPrice() string {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return ""
+}
+
+func (x *BuyoutResponse) GetSoldAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldAt
+	}
+	return nil
+}
+
 var File_item_proto protoreflect.FileDescriptor
 
 const file_item_proto_rawDesc = "" +
@@ -213,7 +377,7 @@ const file_item_proto_rawDesc = "" +
 	"\n" +
 	"item.proto\x12\x0fauction.item.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"/\n" +
 	"\x14GetItemForBidRequest\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"\x94\x04\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"\xda\x04\n" +
 	"\x15GetItemForBidResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x16\n" +
@@ -224,17 +388,34 @@ const file_item_proto_rawDesc = "" +
 	"\vstart_price\x18\x06 \x01(\tR\n" +
 	"startPrice\x12#\n" +
 	"\rcurrent_price\x18\a \x01(\tR\fcurrentPrice\x12#\n" +
-	"\rbid_increment\x18\b \x01(\tR\fbidIncrement\x12#\n" +
-	"\rreserve_price\x18\t \x01(\tR\freservePrice\x12!\n" +
+	"\rbid_increment\x18\b \x01(\tR\fbidIncrement\x12'\n" +
+	"\rreserve_price\x18\t \x01(\tB\x02\x18\x01R\freservePrice\x12!\n" +
 	"\fbuyout_price\x18\n" +
 	" \x01(\tR\vbuyoutPrice\x12\x1b\n" +
 	"\tend_price\x18\v \x01(\tR\bendPrice\x129\n" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2m\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1f\n" +
+	"\vhas_reserve\x18\x0e \x01(\bR\n" +
+	"hasReserve\x12\x1f\n" +
+	"\vreserve_met\x18\x0f \x01(\bR\n" +
+	"reserveMet\"C\n" +
+	"\rBuyoutRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x19\n" +
+	"\bbuyer_id\x18\x02 \x01(\tR\abuyerId\"\xeb\x01\n" +
+	"\x0eBuyoutResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x19\n" +
+	"\bbuyer_id\x18\x03 \x01(\tR\abuyerId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rcurrency_code\x18\x05 \x01(\tR\fcurrencyCode\x12\x1f\n" +
+	"\vfinal_price\x18\x06 \x01(\tR\n" +
+	"finalPrice\x123\n" +
+	"\asold_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06soldAt2\xb8\x01\n" +
 	"\vItemService\x12^\n" +
-	"\rGetItemForBid\x12%.auction.item.v1.GetItemForBidRequest\x1a&.auction.item.v1.GetItemForBidResponseB\"Z auction/proto/gen/item/v1;itemv1b\x06proto3"
+	"\rGetItemForBid\x12%.auction.item.v1.GetItemForBidRequest\x1a&.auction.item.v1.GetItemForBidResponse\x12I\n" +
+	"\x06Buyout\x12\x1e.auction.item.v1.BuyoutRequest\x1a\x1f.auction.item.v1.BuyoutResponseB\"Z auction/proto/gen/item/v1;itemv1b\x06proto3"
 
 var (
 	file_item_proto_rawDescOnce sync.Once
@@ -248,24 +429,29 @@ func file_item_proto_rawDescGZIP() []byte {
 	return file_item_proto_rawDescData
 }
 
-var file_item_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_item_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_item_proto_goTypes = []any{
 	(*GetItemForBidRequest)(nil),  // 0: auction.item.v1.GetItemForBidRequest
 	(*GetItemForBidResponse)(nil), // 1: auction.item.v1.GetItemForBidResponse
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*BuyoutRequest)(nil),         // 2: auction.item.v1.BuyoutRequest
+	(*BuyoutResponse)(nil),        // 3: auction.item.v1.BuyoutResponse
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
 }
 var file_item_proto_depIdxs = []int32{
-	2, // 0: auction.item.v1.GetItemForBidResponse.start_date:type_name -> google.protobuf.Timestamp
-	2, // 1: auction.item.v1.GetItemForBidResponse.end_date:type_name -> google.protobuf.Timestamp
-	2, // 2: auction.item.v1.GetItemForBidResponse.created_at:type_name -> google.protobuf.Timestamp
-	2, // 3: auction.item.v1.GetItemForBidResponse.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 4: auction.item.v1.ItemService.GetItemForBid:input_type -> auction.item.v1.GetItemForBidRequest
-	1, // 5: auction.item.v1.ItemService.GetItemForBid:output_type -> auction.item.v1.GetItemForBidResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4, // 0: auction.item.v1.GetItemForBidResponse.start_date:type_name -> google.protobuf.Timestamp
+	4, // 1: auction.item.v1.GetItemForBidResponse.end_date:type_name -> google.protobuf.Timestamp
+	4, // 2: auction.item.v1.GetItemForBidResponse.created_at:type_name -> google.protobuf.Timestamp
+	4, // 3: auction.item.v1.GetItemForBidResponse.updated_at:type_name -> google.protobuf.Timestamp
+	4, // 4: auction.item.v1.BuyoutResponse.sold_at:type_name -> google.protobuf.Timestamp
+	0, // 5: auction.item.v1.ItemService.GetItemForBid:input_type -> auction.item.v1.GetItemForBidRequest
+	2, // 6: auction.item.v1.ItemService.Buyout:input_type -> auction.item.v1.BuyoutRequest
+	1, // 7: auction.item.v1.ItemService.GetItemForBid:output_type -> auction.item.v1.GetItemForBidResponse
+	3, // 8: auction.item.v1.ItemService.Buyout:output_type -> auction.item.v1.BuyoutResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_item_proto_init() }
@@ -279,7 +465,7 @@ func file_item_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_item_proto_rawDesc), len(file_item_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	ItemService_GetItemForBid_FullMethodName = "/auction.item.v1.ItemService/GetItemForBid"
+	ItemService_Buyout_FullMethodName        = "/auction.item.v1.ItemService/Buyout"
 )
 
 // ItemServiceClient is the client API for ItemService service.
@@ -28,6 +29,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ItemServiceClient interface {
 	GetItemForBid(ctx context.Context, in *GetItemForBidRequest, opts ...grpc.CallOption) (*GetItemForBidResponse, error)
+	Buyout(ctx context.Context, in *BuyoutRequest, opts ...grpc.CallOption) (*BuyoutResponse, error)
 }
 
 type itemServiceClient struct {
@@ -48,11 +50,22 @@ func (c *itemServiceClient) GetItemForBid(ctx context.Context, in *GetItemForBid
 	return out, nil
 }
 
+func (c *itemServiceClient) Buyout(ctx context.Context, in *BuyoutRequest, opts ...grpc.CallOption) (*BuyoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyoutResponse)
+	err := c.cc.Invoke(ctx, ItemService_Buyout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemServiceServer is the server API for ItemService service.
 // All implementations must embed UnimplementedItemServiceServer
 // for forward compatibility.
 type ItemServiceServer interface {
 	GetItemForBid(context.Context, *GetItemForBidRequest) (*GetItemForBidResponse, error)
+	Buyout(context.Context, *BuyoutRequest) (*BuyoutResponse, error)
 	mustEmbedUnimplementedItemServiceServer()
 }
 
@@ -66,6 +79,9 @@ type UnimplementedItemServiceServer struct{}
 func (UnimplementedItemServiceServer) GetItemForBid(context.Context, *GetItemForBidRequest) (*GetItemForBidResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetItemForBid not implemented")
 }
+func (UnimplementedItemServiceServer) Buyout(context.Context, *BuyoutRequest) (*BuyoutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Buyout not implemented")
+}
 func (UnimplementedItemServiceServer) mustEmbedUnimplementedItemServiceServer() {}
 func (UnimplementedItemServiceServer) testEmbeddedByValue()                     {}
 
@@ -105,6 +121,24 @@ func _ItemService_GetItemForBid_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemService_Buyout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).Buyout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_Buyout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).Buyout(ctx, req.(*BuyoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ItemService_ServiceDesc is the grpc.ServiceDesc for ItemService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +150,10 @@ var ItemService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetItemForBid",
 			Handler:    _ItemService_GetItemForBid_Handler,
 		},
+		{
+			MethodName: "Buyout",
+			Handler:    _ItemService_Buyout_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "item.proto",